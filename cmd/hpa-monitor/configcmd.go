@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"hpa-monitor/pkg/config"
+)
+
+const configUsage = `Usage: hpa-monitor config <validate|print> [--config file] [flags]
+
+  validate    Check the effective configuration and report every invalid key
+  print       Print the effective configuration with secrets redacted
+`
+
+// runConfig implements the config subcommand
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		fmt.Print(configUsage)
+		return fmt.Errorf("a config command is required")
+	}
+
+	action := args[0]
+	flags := flag.NewFlagSet("config "+action, flag.ExitOnError)
+	loader := config.NewLoader(flags)
+	flags.Parse(args[1:])
+
+	switch action {
+	case "validate":
+		if _, err := loader.Load(); err != nil {
+			return err
+		}
+		source := "defaults, environment and flags"
+		if loader.ConfigFile() != "" {
+			source = loader.ConfigFile()
+		}
+		fmt.Printf("Configuration is valid (%s)\n", source)
+		return nil
+	case "print":
+		cfg, err := loader.Load()
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(cfg.Redacted())
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(data)
+		return nil
+	default:
+		fmt.Print(configUsage)
+		return fmt.Errorf("unknown config command %q", action)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"hpa-monitor/pkg/client"
+	"hpa-monitor/pkg/export"
+	"hpa-monitor/pkg/k8s"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/server"
+)
+
+// runExport writes the current HPA statuses as CSV, NDJSON or XLSX, read from the
+// cluster or, for history summaries, downloaded from a running server
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", export.FormatCSV, "Export format: csv, ndjson or xlsx")
+	output := flags.String("o", "", "Output file (default: stdout)")
+	serverURL := flags.String("server", "", "Download from a running hpa-monitor, e.g. http://localhost:8080, instead of reading the cluster")
+	withHistory := flags.Bool("history", false, "Add history summaries per HPA (requires --server)")
+	window := flags.String("window", "", "History window such as 24h or 7d (default: the server's retention)")
+	namespaces := flags.String("namespace", "", "Comma-separated namespaces to export (default: all)")
+	selector := flags.String("selector", "", "Kubernetes label selector")
+	status := flags.String("status", "", "Comma-separated status filters: at-max, not-ready, out-of-tolerance")
+	sortKey := flags.String("sort", "", "Sort by name, replicas or ratio, prefixed with - for descending")
+	timeout := flags.Duration("timeout", 30*time.Second, "Timeout for reading the cluster or server")
+	flags.Parse(args)
+
+	// Keep informational logs out of the command output
+	logger.InitLogger("error")
+
+	if _, err := export.ContentType(*format); err != nil {
+		return err
+	}
+	if *withHistory && *serverURL == "" {
+		return fmt.Errorf("--history requires --server: history is recorded in memory by a running hpa-monitor")
+	}
+	if *window != "" {
+		if _, err := export.ParseWindow(*window); err != nil {
+			return err
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if *serverURL != "" {
+		return client.New(*serverURL, nil).Export(ctx, client.ExportOptions{
+			Format:        *format,
+			History:       *withHistory,
+			Window:        *window,
+			Namespaces:    splitList(*namespaces),
+			LabelSelector: *selector,
+			Status:        splitList(*status),
+			Sort:          *sortKey,
+		}, w)
+	}
+
+	kubeClient, err := k8s.NewClient()
+	if err != nil {
+		return err
+	}
+	hpaMonitor := monitor.NewHPAMonitor(kubeClient)
+	hpaMonitor.SetNamespaces(splitList(*namespaces))
+	// Exports have no events column, so events are not fetched
+	hpaStatuses, err := hpaMonitor.ListHPAStatus(ctx, monitor.StatusOptions{SkipEvents: true})
+	if err != nil {
+		return fmt.Errorf("failed to list HPAs: %v", err)
+	}
+
+	query := url.Values{}
+	if *selector != "" {
+		query.Set("labelSelector", *selector)
+	}
+	if *status != "" {
+		query.Set("status", *status)
+	}
+	if *sortKey != "" {
+		query.Set("sort", *sortKey)
+	}
+	if hpaStatuses, err = server.FilterHPAs(hpaStatuses, query); err != nil {
+		return err
+	}
+	return export.Write(w, *format, export.NewRows(hpaStatuses, nil, time.Time{}), false)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,38 +1,60 @@
 package main
 
 import (
-	"hpa-monitor/pkg/config"
-	"hpa-monitor/pkg/k8s"
-	"hpa-monitor/pkg/logger"
-	"hpa-monitor/pkg/monitor"
-	"hpa-monitor/pkg/server"
+	"fmt"
+	"os"
 )
 
-func main() {
-	// Load configuration (this initializes the logger)
-	cfg := config.NewConfig()
-	log := logger.GetLogger()
+const usage = `Usage: hpa-monitor [command] [flags]
 
-	log.Info("HPA Monitor starting up")
+Commands:
+  serve       Start the dashboard server (default)
+  simulate    Replay the HPA controller algorithm over a metric series
+  export      Write HPA statuses as CSV, NDJSON or XLSX
+  snapshot    Write the dashboard as a self-contained HTML file
+  config      Validate or print the effective configuration
+  openapi     Print the OpenAPI document
+`
 
-	// Create Kubernetes client
-	client, err := k8s.NewClient()
-	if err != nil {
-		log.WithError(err).Fatal("Failed to create Kubernetes client")
+func main() {
+	command := "serve"
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		command, args = args[0], args[1:]
 	}
-	log.Info("Kubernetes client created successfully")
-
-	// Create HPA monitor
-	hpaMonitor := monitor.NewHPAMonitor(client)
-	hpaMonitor.SetTolerance(cfg.Tolerance)
-	log.WithField("tolerance", cfg.Tolerance).Info("HPA monitor created")
-
-	// Create and start server
-	srv := server.NewServer(hpaMonitor, cfg)
-	log.Info("Server components initialized")
 
-	// Start server
-	if err := srv.Start(); err != nil {
-		log.WithError(err).Fatal("Failed to start server")
+	switch command {
+	case "serve":
+		runServe(args)
+	case "simulate":
+		if err := runSimulate(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "export":
+		if err := runExport(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "snapshot":
+		if err := runSnapshot(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "config":
+		if err := runConfig(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "openapi":
+		if err := runOpenAPI(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
 	}
-}
\ No newline at end of file
+}
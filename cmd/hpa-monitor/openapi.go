@@ -0,0 +1,19 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"hpa-monitor/pkg/server"
+)
+
+// runOpenAPI implements the openapi subcommand
+func runOpenAPI(args []string) error {
+	flags := flag.NewFlagSet("openapi", flag.ExitOnError)
+	flags.Parse(args)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(server.OpenAPIDocument())
+}
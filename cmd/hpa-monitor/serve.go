@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"hpa-monitor/pkg/alert"
+	"hpa-monitor/pkg/certs"
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/demo"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/k8s"
+	"hpa-monitor/pkg/leader"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/replay"
+	"hpa-monitor/pkg/server"
+	"hpa-monitor/pkg/transition"
+)
+
+// runServe starts the dashboard server
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	demoMode := flags.Bool("demo", false, "Serve a built-in fake cluster with synthetic HPAs instead of a live cluster")
+	replayPath := flags.String("replay", "", "Serve from a recorded NDJSON file instead of a live cluster")
+	speed := flags.Float64("speed", 1.0, "Initial replay playback speed multiplier")
+	recordPath := flags.String("record", "", "Append cluster snapshots to an NDJSON file for later replay")
+	recordInterval := flags.Duration("record-interval", 15*time.Second, "Interval between recorded snapshots")
+	reloadInterval := flags.Duration("reload-interval", 10*time.Second, "Interval between config file and TLS certificate change checks")
+	loader := config.NewLoader(flags)
+	flags.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	logger.InitLogger(cfg.LogLevel)
+	cfg.LogConfig()
+	log := logger.GetLogger()
+
+	log.Info("HPA Monitor starting up")
+
+	// SIGTERM from a rolling update cancels ctx, which stops background work and
+	// drains the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var client kubernetes.Interface
+	var player *replay.Player
+	var recorder *replay.Recorder
+	live := !*demoMode && *replayPath == ""
+	switch {
+	case *demoMode && *replayPath != "":
+		log.Fatal("--demo and --replay cannot be used together")
+	case *demoMode:
+		// Demo mode serves a fake cluster with scripted load curves
+		cluster, err := demo.NewCluster(demo.Scenarios(), time.Now())
+		if err != nil {
+			log.WithError(err).Fatal("Failed to create demo cluster")
+		}
+		client = cluster.Client()
+		go cluster.Run(ctx)
+		log.Info("Demo mode enabled")
+	case *replayPath != "":
+		// Replay mode serves a fake cluster driven by the recording
+		frames, err := replay.LoadFile(*replayPath)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load recording")
+		}
+		player, err = replay.NewPlayer(frames, *speed)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to create replay player")
+		}
+		client = player.Client()
+		go player.Run(ctx)
+		log.WithField("path", *replayPath).Info("Replay mode enabled")
+	default:
+		// Create Kubernetes client
+		var err error
+		client, err = k8s.NewClient()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to create Kubernetes client")
+		}
+		log.Info("Kubernetes client created successfully")
+
+		if *recordPath != "" {
+			recorder = replay.NewRecorder(client, *recordPath, *recordInterval)
+		}
+	}
+
+	// Create HPA monitor
+	hpaMonitor := monitor.NewHPAMonitor(client)
+	hpaMonitor.SetTolerance(cfg.Tolerance)
+	hpaMonitor.SetNamespaces(cfg.Namespaces)
+	hpaMonitor.SetTimeout(time.Duration(cfg.RequestTimeout) * time.Second)
+	runbookTemplate, err := monitor.ParseRunbookTemplate(cfg.RunbookTemplate)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to parse runbook template")
+	}
+	hpaMonitor.SetRunbookTemplate(runbookTemplate)
+	if player != nil {
+		hpaMonitor.SetClock(player.Now)
+	}
+	log.WithField("tolerance", cfg.Tolerance).Info("HPA monitor created")
+
+	historyStore := history.NewStore(
+		time.Duration(cfg.HistoryInterval)*time.Second,
+		time.Duration(cfg.HistoryRetention)*24*time.Hour,
+	)
+	historyStore.SetMaxSamples(cfg.HistoryMaxSamples)
+
+	elector := leader.NewStandalone(cfg.PodName)
+	if cfg.LeaderElection && live {
+		elector = leader.NewElector(client, cfg.LeaderElectionNamespace, cfg.LeaderElectionLease, cfg.PodName)
+	}
+
+	// Create and start server
+	srv := server.NewServer(hpaMonitor, historyStore, elector, cfg)
+	if player != nil {
+		srv.SetReplay(player)
+	}
+
+	// Serve over TLS, reloading the certificate when the mounted files are rotated
+	if cfg.TLSCertFile != "" {
+		reloader, err := certs.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load TLS certificate")
+		}
+		go reloader.Watch(ctx, *reloadInterval)
+		srv.SetTLS(reloader)
+		log.WithFields(logger.Fields{
+			"expires":    reloader.NotAfter().Format(time.RFC3339),
+			"mutual_tls": reloader.MutualTLS(),
+		}).Info("TLS enabled")
+	}
+
+	tracker := transition.NewTracker(cfg.ClusterName, time.Duration(cfg.HistoryRetention)*24*time.Hour)
+	srv.SetTransitions(tracker)
+	notifier := alert.NewNotifier(cfg.ClusterName)
+	notifier.SetRules(cfg.AlertRules, cfg.AlertReceivers)
+
+	// Only the leader polls for history, transitions, alerts and snapshots; every replica
+	// serves the live API and dashboard, and followers refuse history and transition requests
+	go elector.Run(ctx, func(leaderCtx context.Context) {
+		go historyStore.Run(leaderCtx, hpaMonitor)
+		go tracker.Run(leaderCtx, hpaMonitor, time.Duration(cfg.WebSocketInterval)*time.Second, srv.BroadcastTransitions)
+		go notifier.Run(leaderCtx, hpaMonitor, time.Duration(cfg.HistoryInterval)*time.Second)
+		if recorder != nil {
+			go func() {
+				if err := recorder.Run(leaderCtx); err != nil {
+					log.WithError(err).Error("Snapshot recorder failed")
+				}
+			}()
+		}
+	})
+	log.Info("Server components initialized")
+
+	// Reload the config file when it changes, applying the keys that can change live
+	go loader.Watch(ctx, cfg, *reloadInterval, func(next *config.Config, changes []config.Change) {
+		applyConfig(hpaMonitor, tracker, notifier, next, changes)
+		srv.SetConfig(next, changes)
+	})
+
+	// The gRPC API runs on its own port next to the HTTP server and shuts down with it
+	grpcDone := make(chan struct{})
+	if cfg.GRPCPort != "" {
+		go func() {
+			defer close(grpcDone)
+			if err := srv.StartGRPC(ctx); err != nil {
+				log.WithError(err).Error("gRPC server failed")
+			}
+		}()
+	} else {
+		close(grpcDone)
+	}
+
+	// Start server
+	if err := srv.Start(ctx); err != nil {
+		log.WithError(err).Fatal("Server failed")
+	}
+	<-grpcDone
+	log.Info("HPA Monitor shut down")
+}
+
+// liveKeys are the configuration keys applied without a restart
+var liveKeys = map[string]bool{
+	"tolerance":            true,
+	"logLevel":             true,
+	"namespaces":           true,
+	"websocketInterval":    true,
+	"websocketMinInterval": true,
+	"websocketMaxInterval": true,
+	"requestTimeout":       true,
+	"shutdownTimeout":      true,
+	"runbookTemplate":      true,
+	"allowedOrigins":       true,
+	"frameAncestors":       true,
+	"alertRules":           true,
+	"alertReceivers":       true,
+}
+
+// applyConfig applies a reloaded configuration to the running components
+func applyConfig(hpaMonitor *monitor.HPAMonitor, tracker *transition.Tracker, notifier *alert.Notifier, cfg *config.Config, changes []config.Change) {
+	log := logger.GetLogger()
+
+	for _, change := range changes {
+		switch change.Key {
+		case "tolerance":
+			hpaMonitor.SetTolerance(cfg.Tolerance)
+		case "logLevel":
+			logger.SetLevel(cfg.LogLevel)
+		case "namespaces":
+			hpaMonitor.SetNamespaces(cfg.Namespaces)
+		case "websocketInterval":
+			// Open connections pick up the new interval from the configReloaded broadcast
+			tracker.SetInterval(time.Duration(cfg.WebSocketInterval) * time.Second)
+		case "requestTimeout":
+			hpaMonitor.SetTimeout(time.Duration(cfg.RequestTimeout) * time.Second)
+		case "runbookTemplate":
+			// Validation already parsed the template, so this cannot fail
+			runbookTemplate, _ := monitor.ParseRunbookTemplate(cfg.RunbookTemplate)
+			hpaMonitor.SetRunbookTemplate(runbookTemplate)
+		case "alertRules", "alertReceivers":
+			notifier.SetRules(cfg.AlertRules, cfg.AlertReceivers)
+		}
+		if !liveKeys[change.Key] {
+			log.WithField("key", change.Key).Warn("Configuration change requires a restart to take effect")
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+
+	"hpa-monitor/pkg/k8s"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/simulate"
+)
+
+// runSimulate replays the HPA controller algorithm and prints the replica trajectory
+func runSimulate(args []string) error {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	manifest := flags.String("f", "", "Path to an autoscaling/v2 HPA manifest (YAML or JSON)")
+	namespace := flags.String("namespace", "default", "Namespace of an existing HPA")
+	name := flags.String("name", "", "Name of an existing HPA in the cluster")
+	seriesPath := flags.String("series", "", "Path to the metric series (JSON array of {offset, value} or offset,value CSV)")
+	initial := flags.Int("initial-replicas", 0, "Starting replica count (default: HPA current replicas or minReplicas)")
+	step := flags.Int("step", simulate.DefaultStepSeconds, "Controller sync period in seconds")
+	tolerance := flags.Float64("tolerance", simulate.DefaultTolerance, "Scaling tolerance (0.0 to 1.0) (default: the tolerance annotation of an existing HPA, otherwise 0.1)")
+	output := flags.String("o", "table", "Output format: table or json")
+	flags.Parse(args)
+
+	// Keep informational logs out of the command output
+	logger.InitLogger("error")
+
+	if *seriesPath == "" {
+		return fmt.Errorf("--series is required")
+	}
+	seriesData, err := os.ReadFile(*seriesPath)
+	if err != nil {
+		return fmt.Errorf("failed to read series: %v", err)
+	}
+	series, err := simulate.ParseSeries(seriesData)
+	if err != nil {
+		return err
+	}
+
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	switch {
+	case *manifest != "":
+		data, err := os.ReadFile(*manifest)
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %v", err)
+		}
+		if hpa, err = simulate.ParseManifest(data); err != nil {
+			return err
+		}
+	case *name != "":
+		client, err := k8s.NewClient()
+		if err != nil {
+			return err
+		}
+		hpaMonitor := monitor.NewHPAMonitor(client)
+		hpaMonitor.SetTolerance(simulate.DefaultTolerance)
+		if hpa, err = hpaMonitor.GetHPA(context.Background(), *namespace, *name); err != nil {
+			return fmt.Errorf("failed to get HPA %s/%s: %v", *namespace, *name, err)
+		}
+		if !flagSet(flags, "tolerance") {
+			*tolerance = hpaMonitor.EffectiveTolerance(context.Background(), hpa)
+		}
+	default:
+		return fmt.Errorf("either -f or --name is required")
+	}
+
+	result, err := simulate.Run(simulate.Input{
+		HPA:             hpa,
+		Series:          series,
+		InitialReplicas: int32(*initial),
+		StepSeconds:     *step,
+		Tolerance:       *tolerance,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "table":
+		return printSimulation(result)
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
+
+// printSimulation writes the simulation steps as an aligned table
+func printSimulation(result *simulate.Result) error {
+	fmt.Printf("HPA %s/%s metric=%s target=%g tolerance=up %g/down %g\n\n",
+		result.Namespace, result.Name, result.MetricName, result.Target, result.ScaleUpTolerance, result.ScaleDownTolerance)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tMETRIC\tRATIO\tCURRENT\tPROPOSED\tSTABILIZED\tDESIRED\tREASON")
+	for _, step := range result.Steps {
+		fmt.Fprintf(w, "%s\t%g\t%.2f\t%d\t%d\t%d\t%d\t%s\n",
+			simulate.FormatOffset(step.Offset), step.Metric, step.Ratio, step.CurrentReplicas,
+			step.ProposedReplicas, step.StabilizedReplicas, step.DesiredReplicas, step.Reason)
+	}
+	return w.Flush()
+}
+
+// flagSet reports whether the named flag was given on the command line
+func flagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"hpa-monitor/pkg/client"
+	"hpa-monitor/pkg/export"
+	"hpa-monitor/pkg/k8s"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/server"
+	"hpa-monitor/pkg/snapshot"
+	"hpa-monitor/web"
+)
+
+// runSnapshot writes the dashboard's current state as a self-contained HTML file,
+// rendered from the cluster or, for sparklines, downloaded from a running server
+func runSnapshot(args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	out := flags.String("out", "hpa-snapshot.html", "Output HTML file, - for stdout")
+	serverURL := flags.String("server", "", "Download from a running hpa-monitor, e.g. http://localhost:8080, to include history sparklines")
+	window := flags.String("window", "", "History drawn in sparklines such as 6h or 7d (default: 24h, requires --server)")
+	namespaces := flags.String("namespace", "", "Comma-separated namespaces to include (default: all)")
+	selector := flags.String("selector", "", "Kubernetes label selector")
+	status := flags.String("status", "", "Comma-separated status filters: at-max, not-ready, out-of-tolerance")
+	sortKey := flags.String("sort", "", "Sort by name, replicas or ratio, prefixed with - for descending")
+	clusterName := flags.String("cluster-name", os.Getenv("CLUSTER_NAME"), "Cluster name shown in the report header")
+	timeout := flags.Duration("timeout", 30*time.Second, "Timeout for reading the cluster or server")
+	flags.Parse(args)
+
+	// Keep informational logs out of the command output
+	logger.InitLogger("error")
+
+	if *window != "" {
+		if *serverURL == "" {
+			return fmt.Errorf("--window requires --server: history is recorded in memory by a running hpa-monitor")
+		}
+		if _, err := export.ParseWindow(*window); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	// Render fully before creating the file, so a failure does not leave a partial report
+	var page bytes.Buffer
+	if *serverURL != "" {
+		err := client.New(*serverURL, nil).Snapshot(ctx, client.SnapshotOptions{
+			Window:        *window,
+			Namespaces:    splitList(*namespaces),
+			LabelSelector: *selector,
+			Status:        splitList(*status),
+			Sort:          *sortKey,
+		}, &page)
+		if err != nil {
+			return err
+		}
+	} else {
+		kubeClient, err := k8s.NewClient()
+		if err != nil {
+			return err
+		}
+		hpaMonitor := monitor.NewHPAMonitor(kubeClient)
+		hpaMonitor.SetNamespaces(splitList(*namespaces))
+		hpaStatuses, err := hpaMonitor.GetHPAStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list HPAs: %v", err)
+		}
+
+		query := url.Values{}
+		if *selector != "" {
+			query.Set("labelSelector", *selector)
+		}
+		if *status != "" {
+			query.Set("status", *status)
+		}
+		if *sortKey != "" {
+			query.Set("sort", *sortKey)
+		}
+		if hpaStatuses, err = server.FilterHPAs(hpaStatuses, query); err != nil {
+			return err
+		}
+		err = snapshot.Render(&page, web.FS(""), hpaStatuses, snapshot.Options{
+			Cluster:     *clusterName,
+			GeneratedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if *out == "-" {
+		_, err := os.Stdout.Write(page.Bytes())
+		return err
+	}
+	if err := os.WriteFile(*out, page.Bytes(), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", *out)
+	return nil
+}
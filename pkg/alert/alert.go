@@ -0,0 +1,294 @@
+// Package alert evaluates the configured alert rules against HPA statuses and posts
+// notifications to their webhook receivers.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+)
+
+// Notification statuses
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// sendTimeout bounds each webhook request
+const sendTimeout = 10 * time.Second
+
+// Notification is the JSON body posted to a receiver's webhook
+type Notification struct {
+	Status  string        `json:"status"`
+	Rule    string        `json:"rule"`
+	Cluster string        `json:"cluster,omitempty"`
+	Alert   monitor.Alert `json:"alert"`
+	At      time.Time     `json:"at"`
+}
+
+// delivery is a notification addressed to one receiver
+type delivery struct {
+	receiver     config.AlertReceiver
+	notification Notification
+}
+
+// match is an alert a rule currently matches
+type match struct {
+	alert     monitor.Alert
+	firstSeen time.Time
+	notified  bool
+}
+
+// Notifier notifies the receivers of each rule once when a matching alert fires and once
+// when it resolves
+type Notifier struct {
+	mu        sync.Mutex
+	cluster   string
+	client    *http.Client
+	rules     []config.AlertRule
+	receivers map[string]config.AlertReceiver
+	// matches is keyed by rule name and HPA key
+	matches map[string]*match
+}
+
+// NewNotifier creates a notifier that labels notifications with cluster
+func NewNotifier(cluster string) *Notifier {
+	return &Notifier{
+		cluster:   cluster,
+		client:    &http.Client{Timeout: sendTimeout},
+		receivers: make(map[string]config.AlertReceiver),
+		matches:   make(map[string]*match),
+	}
+}
+
+// SetRules replaces the rules and receivers. Alerts of a removed or changed rule are
+// forgotten without a resolved notification; those of unchanged rules are kept.
+func (n *Notifier) SetRules(rules []config.AlertRule, receivers []config.AlertReceiver) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	previous := make(map[string]config.AlertRule, len(n.rules))
+	for _, rule := range n.rules {
+		previous[rule.Name] = rule
+	}
+	kept := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if old, ok := previous[rule.Name]; ok && reflect.DeepEqual(old, rule) {
+			kept[rule.Name] = true
+		}
+	}
+	for key := range n.matches {
+		ruleName, _, _ := strings.Cut(key, "|")
+		if !kept[ruleName] {
+			delete(n.matches, key)
+		}
+	}
+
+	n.rules = rules
+	n.receivers = make(map[string]config.AlertReceiver, len(receivers))
+	for _, receiver := range receivers {
+		n.receivers[receiver.Name] = receiver
+	}
+}
+
+// Run evaluates the rules every interval and sends the resulting notifications until ctx
+// is cancelled. HPAs are only polled while rules are configured. Each run starts without
+// matches, so a new leader notifies alerts that are already firing again.
+func (n *Notifier) Run(ctx context.Context, hpaMonitor *monitor.HPAMonitor, interval time.Duration) {
+	log := logger.GetLogger()
+	log.WithField("interval", interval.String()).Info("Alert notifier started")
+
+	n.mu.Lock()
+	n.matches = make(map[string]*match)
+	n.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n.hasRules() {
+			// Alerts come from conditions, so events are not fetched
+			statuses, err := hpaMonitor.ListHPAStatus(ctx, monitor.StatusOptions{SkipEvents: true})
+			if err != nil {
+				log.WithError(err).Error("Failed to collect HPA status for alerts")
+			} else {
+				for _, d := range n.evaluate(statuses, hpaMonitor.Now()) {
+					n.send(ctx, d)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Alert notifier stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// hasRules reports whether any rule is configured
+func (n *Notifier) hasRules() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.rules) > 0
+}
+
+// evaluate matches the alerts of statuses against every rule and returns a firing
+// delivery per receiver for alerts that started firing, and a resolved one for notified
+// alerts that no longer match
+func (n *Notifier) evaluate(statuses []monitor.HPAStatus, now time.Time) []delivery {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	alerts := monitor.Alerts(statuses, now)
+	var deliveries []delivery
+	seen := make(map[string]bool)
+	for _, rule := range n.rules {
+		for _, alert := range alerts {
+			if !matches(rule, alert) {
+				continue
+			}
+			key := rule.Name + "|" + history.Key(alert.Namespace, alert.Name)
+			seen[key] = true
+			m, ok := n.matches[key]
+			if !ok {
+				m = &match{firstSeen: now}
+				n.matches[key] = m
+			}
+			m.alert = alert
+			if !m.notified && firing(rule, alert, m.firstSeen, now) {
+				m.notified = true
+				deliveries = append(deliveries, n.deliveries(rule, StatusFiring, alert, now)...)
+			}
+		}
+	}
+
+	for key, m := range n.matches {
+		if seen[key] {
+			continue
+		}
+		delete(n.matches, key)
+		if !m.notified {
+			continue
+		}
+		ruleName, _, _ := strings.Cut(key, "|")
+		for _, rule := range n.rules {
+			if rule.Name == ruleName {
+				deliveries = append(deliveries, n.deliveries(rule, StatusResolved, m.alert, now)...)
+			}
+		}
+	}
+	return deliveries
+}
+
+// deliveries addresses one notification to each receiver of rule
+func (n *Notifier) deliveries(rule config.AlertRule, status string, alert monitor.Alert, now time.Time) []delivery {
+	notification := Notification{
+		Status:  status,
+		Rule:    rule.Name,
+		Cluster: n.cluster,
+		Alert:   alert,
+		At:      now,
+	}
+	result := make([]delivery, 0, len(rule.Receivers))
+	for _, name := range rule.Receivers {
+		if receiver, ok := n.receivers[name]; ok {
+			result = append(result, delivery{receiver: receiver, notification: notification})
+		}
+	}
+	return result
+}
+
+// matches reports whether alert is in the rule's namespaces and has one of its reasons
+func matches(rule config.AlertRule, alert monitor.Alert) bool {
+	if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, alert.Namespace) {
+		return false
+	}
+	return len(rule.Reasons) == 0 || slices.Contains(rule.Reasons, alert.Reason)
+}
+
+// firing reports whether alert has lasted longer than the rule's for duration, counted
+// from the condition change or else from when it was first matched. Without a for
+// duration the alert's own firing state applies.
+func firing(rule config.AlertRule, alert monitor.Alert, firstSeen, now time.Time) bool {
+	forDuration, err := time.ParseDuration(rule.For)
+	if rule.For == "" || err != nil {
+		return alert.Firing
+	}
+	since := firstSeen
+	if alert.Since != nil {
+		since = *alert.Since
+	}
+	return now.Sub(since) >= forDuration
+}
+
+// send posts one notification, logging failures; notifications are not retried
+func (n *Notifier) send(ctx context.Context, d delivery) {
+	log := logger.GetLogger().WithFields(logger.Fields{
+		"rule":      d.notification.Rule,
+		"receiver":  d.receiver.Name,
+		"status":    d.notification.Status,
+		"namespace": d.notification.Alert.Namespace,
+		"name":      d.notification.Alert.Name,
+	})
+
+	if err := n.post(ctx, d); err != nil {
+		log.WithError(err).Error("Failed to send alert notification")
+		return
+	}
+	log.Info("Alert notification sent")
+}
+
+// post sends the notification to the receiver's webhook
+func (n *Notifier) post(ctx context.Context, d delivery) error {
+	webhookURL := d.receiver.WebhookURL
+	if d.receiver.WebhookURLFile != "" {
+		data, err := os.ReadFile(d.receiver.WebhookURLFile)
+		if err != nil {
+			return fmt.Errorf("reading webhook URL: %v", err)
+		}
+		webhookURL = strings.TrimSpace(string(data))
+	}
+
+	body, err := json.Marshal(d.notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		// The URL may carry a token, so only the failure is reported
+		return fmt.Errorf("invalid webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// url.Error repeats the URL, which may carry a token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("posting to webhook: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/monitor"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// unhealthy returns an HPA status alerting on reason since the given time
+func unhealthy(namespace, name, reason string, since time.Time) monitor.HPAStatus {
+	return monitor.HPAStatus{
+		Namespace:     namespace,
+		Name:          name,
+		DerivedStatus: reason + " – explanation",
+		Conditions: []monitor.Condition{
+			{Type: "ScalingActive", Status: "False", Reason: reason, LastTransitionTime: since.Format(time.RFC3339)},
+		},
+	}
+}
+
+// summary lists deliveries as "status rule receiver namespace/name"
+func summary(deliveries []delivery) []string {
+	var result []string
+	for _, d := range deliveries {
+		n := d.notification
+		result = append(result, n.Status+" "+n.Rule+" "+d.receiver.Name+" "+n.Alert.Namespace+"/"+n.Alert.Name)
+	}
+	return result
+}
+
+func sorted(items []string) []string {
+	result := slices.Clone(items)
+	slices.Sort(result)
+	return result
+}
+
+func TestEvaluate(t *testing.T) {
+	n := NewNotifier("prod")
+	n.SetRules([]config.AlertRule{
+		{Name: "shop-metrics", Namespaces: []string{"shop"}, Reasons: []string{"FailedGetResourceMetric"}, Receivers: []string{"team-shop"}},
+		{Name: "stuck", For: "10m", Receivers: []string{"oncall"}},
+	}, []config.AlertReceiver{
+		{Name: "team-shop", WebhookURL: "https://hooks.example.com/shop"},
+		{Name: "oncall", WebhookURL: "https://hooks.example.com/oncall"},
+	})
+
+	steps := []struct {
+		at       time.Duration
+		statuses []monitor.HPAStatus
+		want     []string
+	}{
+		{
+			// The shop rule fires right away; the stuck rule waits for its for duration
+			at: 0,
+			statuses: []monitor.HPAStatus{
+				unhealthy("shop", "checkout", "FailedGetResourceMetric", start),
+				unhealthy("batch", "report-worker", "FailedGetScale", start),
+				{Namespace: "shop", Name: "storefront", DerivedStatus: monitor.DerivedStatusHealthy},
+			},
+			want: []string{"firing shop-metrics team-shop shop/checkout"},
+		},
+		{
+			at: 5 * time.Minute,
+			statuses: []monitor.HPAStatus{
+				unhealthy("shop", "checkout", "FailedGetResourceMetric", start),
+				unhealthy("batch", "report-worker", "FailedGetScale", start),
+			},
+			want: nil,
+		},
+		{
+			at: 10 * time.Minute,
+			statuses: []monitor.HPAStatus{
+				unhealthy("shop", "checkout", "FailedGetResourceMetric", start),
+				unhealthy("batch", "report-worker", "FailedGetScale", start),
+			},
+			want: []string{
+				"firing stuck oncall shop/checkout",
+				"firing stuck oncall batch/report-worker",
+			},
+		},
+		{
+			// Each notified alert resolves once
+			at: 11 * time.Minute,
+			statuses: []monitor.HPAStatus{
+				unhealthy("batch", "report-worker", "FailedGetScale", start),
+			},
+			want: []string{
+				"resolved shop-metrics team-shop shop/checkout",
+				"resolved stuck oncall shop/checkout",
+			},
+		},
+	}
+	for _, step := range steps {
+		got := summary(n.evaluate(step.statuses, start.Add(step.at)))
+		// Resolved deliveries come from a map, so only their set is fixed
+		if !slices.Equal(sorted(got), sorted(step.want)) {
+			t.Errorf("at %s: deliveries %v, want %v", step.at, got, step.want)
+		}
+	}
+}
+
+func TestSetRulesKeepsUnchangedRules(t *testing.T) {
+	receivers := []config.AlertReceiver{{Name: "oncall", WebhookURL: "https://hooks.example.com/oncall"}}
+	rule := config.AlertRule{Name: "any", Receivers: []string{"oncall"}}
+	n := NewNotifier("")
+	n.SetRules([]config.AlertRule{rule}, receivers)
+	statuses := []monitor.HPAStatus{unhealthy("shop", "checkout", "FailedGetScale", start)}
+	if got := summary(n.evaluate(statuses, start)); len(got) != 1 {
+		t.Fatalf("deliveries %v, want one firing", got)
+	}
+
+	// Reloading the same rule must not notify again
+	n.SetRules([]config.AlertRule{rule}, receivers)
+	if got := summary(n.evaluate(statuses, start.Add(time.Minute))); len(got) != 0 {
+		t.Errorf("after reloading an unchanged rule: deliveries %v, want none", got)
+	}
+
+	// A changed rule starts over
+	changed := rule
+	changed.Namespaces = []string{"shop"}
+	n.SetRules([]config.AlertRule{changed}, receivers)
+	if got := summary(n.evaluate(statuses, start.Add(2*time.Minute))); !slices.Equal(got, []string{"firing any oncall shop/checkout"}) {
+		t.Errorf("after changing the rule: deliveries %v, want it to fire again", got)
+	}
+}
+
+func TestSend(t *testing.T) {
+	received := make(chan Notification, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("webhook request %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		var notification Notification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Errorf("decoding notification: %v", err)
+		}
+		received <- notification
+	}))
+	defer webhook.Close()
+
+	n := NewNotifier("prod")
+	n.SetRules([]config.AlertRule{{Name: "any", Receivers: []string{"hook"}}},
+		[]config.AlertReceiver{{Name: "hook", WebhookURL: webhook.URL}})
+	deliveries := n.evaluate([]monitor.HPAStatus{unhealthy("shop", "checkout", "FailedGetScale", start)}, start)
+	if len(deliveries) != 1 {
+		t.Fatalf("deliveries %v, want one", summary(deliveries))
+	}
+	if err := n.post(t.Context(), deliveries[0]); err != nil {
+		t.Fatalf("posting: %v", err)
+	}
+
+	notification := <-received
+	if notification.Status != StatusFiring || notification.Rule != "any" || notification.Cluster != "prod" ||
+		notification.Alert.Name != "checkout" || notification.Alert.Reason != "FailedGetScale" {
+		t.Errorf("notification %+v, want checkout firing under rule any in prod", notification)
+	}
+}
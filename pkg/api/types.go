@@ -0,0 +1,79 @@
+// Package api defines the JSON bodies of the HTTP API that are not domain types
+// of their own, shared by the server, the OpenAPI document and the Go client.
+package api
+
+import (
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/leader"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/simulate"
+	"hpa-monitor/pkg/transition"
+)
+
+// HPAList is a page of HPAs. When fields are selected, items only carry those fields.
+type HPAList struct {
+	Items    []monitor.HPAStatus `json:"items"`
+	Total    int                 `json:"total"`
+	Continue string              `json:"continue,omitempty"`
+}
+
+// ConditionHistory lists the condition transitions recorded for one HPA
+type ConditionHistory struct {
+	Namespace   string                        `json:"namespace"`
+	Name        string                        `json:"name"`
+	Transitions []history.ConditionTransition `json:"transitions"`
+}
+
+// TransitionList lists scale transitions, oldest first
+type TransitionList struct {
+	Transitions []transition.ScaleTransition `json:"transitions"`
+}
+
+// EventSummary counts HPA events per category, most frequent first
+type EventSummary struct {
+	Categories []monitor.CategoryCount `json:"categories"`
+}
+
+// SimulateRequest is the body of a simulation request. Either Manifest or
+// Namespace and Name select the HPA. Without Tolerance the HPA's effective tolerance is
+// used: its annotation, its namespace's annotation or the global tolerance.
+type SimulateRequest struct {
+	Namespace       string           `json:"namespace,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	Manifest        string           `json:"manifest,omitempty"`
+	Series          []simulate.Point `json:"series"`
+	InitialReplicas int32            `json:"initialReplicas,omitempty"`
+	StepSeconds     int              `json:"stepSeconds,omitempty"`
+	Tolerance       *float64         `json:"tolerance,omitempty"`
+}
+
+// ReplayControl changes replay playback; unset fields are left unchanged
+type ReplayControl struct {
+	Speed  *float64 `json:"speed,omitempty"`
+	Paused *bool    `json:"paused,omitempty"`
+	Seek   *string  `json:"seek,omitempty"`
+}
+
+// ConfigInfo is the configuration exposed to the dashboard
+type ConfigInfo struct {
+	WebSocketInterval int      `json:"websocketInterval"`
+	Tolerance         float64  `json:"tolerance"`
+	Namespaces        []string `json:"namespaces"`
+	Replay            bool     `json:"replay"`
+}
+
+// VersionInfo reports the build version and leader election state
+type VersionInfo struct {
+	Version string        `json:"version"`
+	Leader  leader.Status `json:"leader"`
+}
+
+// Health is the body of the health check
+type Health struct {
+	Status string `json:"status"`
+}
+
+// Error is the body of every error response
+type Error struct {
+	Error string `json:"error"`
+}
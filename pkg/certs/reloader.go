@@ -0,0 +1,151 @@
+// Package certs serves a TLS certificate and client CA bundle from files, reloading
+// them when they change, such as when cert-manager rotates a mounted Secret.
+package certs
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"hpa-monitor/pkg/logger"
+)
+
+// Reloader holds the current certificate and client CAs read from files
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	hash      string
+}
+
+// NewReloader reads the certificate and key, and the client CA bundle when clientCAFile
+// is set, failing when any of them is missing or invalid
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// load reads the files and replaces the current certificate and client CAs
+func (r *Reloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %v", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		bundle, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle: %v", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bundle) {
+			return errors.New("failed to load client CA bundle: no PEM certificates found")
+		}
+	}
+
+	hash := r.filesHash()
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.hash = hash
+	r.mu.Unlock()
+	return nil
+}
+
+// MutualTLS reports whether clients must present a certificate signed by the client CAs
+func (r *Reloader) MutualTLS() bool {
+	return r.clientCAFile != ""
+}
+
+// TLSConfig returns a server configuration that uses the current certificate and client
+// CAs for every handshake. nextProtos are the ALPN protocols offered, e.g. h2 for gRPC.
+func (r *Reloader) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+			if r.clientCAs != nil {
+				config.ClientCAs = r.clientCAs
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
+
+// Watch polls the files and reloads them when their content changes. A certificate that
+// fails to load is logged and the previous one is kept, so a half-written rotation does
+// not take the server down.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	log := logger.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Content hashing also catches Secret updates, which swap a symlink
+			r.mu.RLock()
+			last := r.hash
+			r.mu.RUnlock()
+			if r.filesHash() == last {
+				continue
+			}
+			if err := r.load(); err != nil {
+				log.WithError(err).Error("Rejected TLS certificate reload, keeping previous certificate")
+				// Remember the rejected files so the error is logged once per change
+				r.mu.Lock()
+				r.hash = r.filesHash()
+				r.mu.Unlock()
+				continue
+			}
+			log.WithField("expires", r.NotAfter().Format(time.RFC3339)).Info("Reloaded TLS certificate")
+		}
+	}
+}
+
+// NotAfter returns the expiry of the current certificate
+func (r *Reloader) NotAfter() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// LoadX509KeyPair parses the leaf certificate
+	return r.cert.Leaf.NotAfter
+}
+
+// filesHash returns the SHA-256 of the watched files' contents
+func (r *Reloader) filesHash() string {
+	sum := sha256.New()
+	for _, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		data, _ := os.ReadFile(path)
+		sum.Write(data)
+		// Separate the files so moving bytes between them changes the hash
+		sum.Write([]byte{0})
+	}
+	return string(sum.Sum(nil))
+}
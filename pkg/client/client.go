@@ -0,0 +1,394 @@
+// Package client is a typed Go client for the hpa-monitor HTTP API. Request and
+// response types are the ones the server encodes, described in /api/openapi.json.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"hpa-monitor/pkg/api"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/recommend"
+	"hpa-monitor/pkg/replay"
+	"hpa-monitor/pkg/simulate"
+)
+
+// defaultTimeout applies when New is given no HTTP client
+const defaultTimeout = 30 * time.Second
+
+// Routes called by the client, in OpenAPI path syntax
+const (
+	routeHPAs             = "/api/v1/hpas"
+	routeHPA              = "/api/v1/namespaces/{namespace}/hpas/{name}"
+	routeRecommendations  = "/api/v1/namespaces/{namespace}/hpas/{name}/recommendations"
+	routeConditionHistory = "/api/v1/namespaces/{namespace}/hpas/{name}/conditions"
+	routeTransitions      = "/api/v1/transitions"
+	routeEventSummary     = "/api/v1/events/summary"
+	routeExport           = "/api/v1/export"
+	routeSnapshot         = "/api/v1/snapshot.html"
+	routeSimulate         = "/api/v1/simulate"
+	routeConfig           = "/api/v1/config"
+	routeVersion          = "/api/v1/version"
+	routeReplay           = "/api/v1/replay"
+	routeHealth           = "/health"
+)
+
+// Routes lists every operation the client calls as "METHOD path", so tooling can
+// check them against the server's OpenAPI document
+var Routes = []string{
+	http.MethodGet + " " + routeHPAs,
+	http.MethodGet + " " + routeHPA,
+	http.MethodGet + " " + routeRecommendations,
+	http.MethodGet + " " + routeConditionHistory,
+	http.MethodGet + " " + routeTransitions,
+	http.MethodGet + " " + routeEventSummary,
+	http.MethodGet + " " + routeExport,
+	http.MethodGet + " " + routeSnapshot,
+	http.MethodPost + " " + routeSimulate,
+	http.MethodGet + " " + routeConfig,
+	http.MethodGet + " " + routeVersion,
+	http.MethodGet + " " + routeReplay,
+	http.MethodPost + " " + routeReplay,
+	http.MethodGet + " " + routeHealth,
+}
+
+// pathParamPattern matches OpenAPI path parameters such as {namespace}
+var pathParamPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// Client calls the /api/v1 routes of one hpa-monitor server
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// APIError is returned for responses with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("hpa-monitor API: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ListOptions are the filters, sort order and paging of ListHPAs
+type ListOptions struct {
+	Namespaces    []string
+	LabelSelector string
+	// Status filters that must all match: at-max, not-ready, out-of-tolerance
+	Status []string
+	// Sort is name, replicas or ratio, prefixed with - for descending
+	Sort     string
+	Limit    int
+	Continue string
+	// Fields to return, or to drop when prefixed with -, e.g. -events
+	Fields []string
+}
+
+// ExportOptions select the format, history window and HPAs of Export
+type ExportOptions struct {
+	// Format is csv, ndjson or xlsx; empty means csv
+	Format string
+	// History adds a summary of each HPA's recorded history over Window, e.g. 24h or 7d
+	History       bool
+	Window        string
+	Namespaces    []string
+	LabelSelector string
+	Status        []string
+	Sort          string
+}
+
+// SnapshotOptions select the HPAs of Snapshot and the history drawn in its sparklines
+type SnapshotOptions struct {
+	// Window is the history drawn per HPA, e.g. 6h or 7d; empty means 24h
+	Window        string
+	Namespaces    []string
+	LabelSelector string
+	Status        []string
+	Sort          string
+}
+
+// New creates a client for the server at baseURL, e.g. http://hpa-monitor:8080 or, behind
+// a proxy serving it under a path, https://tools.example.com/hpa-monitor.
+// A nil httpClient uses one with a 30 second timeout.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// ListHPAs returns one page of HPAs; pass the returned Continue in the options to get the next one
+func (c *Client) ListHPAs(ctx context.Context, opts ListOptions) (*api.HPAList, error) {
+	query := filterQuery(opts.Namespaces, opts.LabelSelector, opts.Status, opts.Sort)
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Continue != "" {
+		query.Set("continue", opts.Continue)
+	}
+	if len(opts.Fields) > 0 {
+		query.Set("fields", strings.Join(opts.Fields, ","))
+	}
+
+	var list api.HPAList
+	if err := c.do(ctx, http.MethodGet, routeHPAs, query, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListAllHPAs follows continue tokens and returns every matching HPA
+func (c *Client) ListAllHPAs(ctx context.Context, opts ListOptions) ([]monitor.HPAStatus, error) {
+	var all []monitor.HPAStatus
+	for {
+		list, err := c.ListHPAs(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, list.Items...)
+		if list.Continue == "" {
+			return all, nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
+// GetHPA returns the full status of one HPA
+func (c *Client) GetHPA(ctx context.Context, namespace, name string) (*monitor.HPAStatus, error) {
+	var status monitor.HPAStatus
+	if err := c.do(ctx, http.MethodGet, expand(routeHPA, namespace, name), nil, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Recommendations returns tuning recommendations computed from the server's history
+func (c *Client) Recommendations(ctx context.Context, namespace, name string) (*recommend.Report, error) {
+	var report recommend.Report
+	if err := c.do(ctx, http.MethodGet, expand(routeRecommendations, namespace, name), nil, nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ConditionHistory returns the condition transitions recorded for one HPA
+func (c *Client) ConditionHistory(ctx context.Context, namespace, name string) (*api.ConditionHistory, error) {
+	var conditions api.ConditionHistory
+	if err := c.do(ctx, http.MethodGet, expand(routeConditionHistory, namespace, name), nil, nil, &conditions); err != nil {
+		return nil, err
+	}
+	return &conditions, nil
+}
+
+// Transitions returns the scale transitions since the given time; a zero time returns all retained ones
+func (c *Client) Transitions(ctx context.Context, since time.Time) (*api.TransitionList, error) {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+	var list api.TransitionList
+	if err := c.do(ctx, http.MethodGet, routeTransitions, query, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// EventSummary returns HPA event counts per category across the cluster
+func (c *Client) EventSummary(ctx context.Context) (*api.EventSummary, error) {
+	var summary api.EventSummary
+	if err := c.do(ctx, http.MethodGet, routeEventSummary, nil, nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Export writes every matching HPA to w in the requested format
+func (c *Client) Export(ctx context.Context, opts ExportOptions, w io.Writer) error {
+	query := filterQuery(opts.Namespaces, opts.LabelSelector, opts.Status, opts.Sort)
+	if opts.Format != "" {
+		query.Set("format", opts.Format)
+	}
+	if opts.History {
+		query.Set("history", "true")
+	}
+	if opts.Window != "" {
+		query.Set("window", opts.Window)
+	}
+	return c.download(ctx, routeExport, query, w)
+}
+
+// Snapshot writes the dashboard's current state as a self-contained HTML file to w
+func (c *Client) Snapshot(ctx context.Context, opts SnapshotOptions, w io.Writer) error {
+	query := filterQuery(opts.Namespaces, opts.LabelSelector, opts.Status, opts.Sort)
+	if opts.Window != "" {
+		query.Set("window", opts.Window)
+	}
+	return c.download(ctx, routeSnapshot, query, w)
+}
+
+// Simulate replays the HPA controller algorithm over a metric series
+func (c *Client) Simulate(ctx context.Context, req api.SimulateRequest) (*simulate.Result, error) {
+	var result simulate.Result
+	if err := c.do(ctx, http.MethodPost, routeSimulate, nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Config returns the configuration the server exposes to the dashboard
+func (c *Client) Config(ctx context.Context) (*api.ConfigInfo, error) {
+	var info api.ConfigInfo
+	if err := c.do(ctx, http.MethodGet, routeConfig, nil, nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Version returns the server's build version and leader election state
+func (c *Client) Version(ctx context.Context) (*api.VersionInfo, error) {
+	var info api.VersionInfo
+	if err := c.do(ctx, http.MethodGet, routeVersion, nil, nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// ReplayStatus returns the replay playback position of a server in replay mode
+func (c *Client) ReplayStatus(ctx context.Context) (*replay.Status, error) {
+	var status replay.Status
+	if err := c.do(ctx, http.MethodGet, routeReplay, nil, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ControlReplay changes replay speed, pauses or resumes, and seeks
+func (c *Client) ControlReplay(ctx context.Context, control api.ReplayControl) (*replay.Status, error) {
+	var status replay.Status
+	if err := c.do(ctx, http.MethodPost, routeReplay, nil, control, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Health returns nil when the server reports itself healthy
+func (c *Client) Health(ctx context.Context) error {
+	var health api.Health
+	return c.do(ctx, http.MethodGet, routeHealth, nil, nil, &health)
+}
+
+// filterQuery returns the HPA filter and sort parameters shared by the list, export and snapshot routes
+func filterQuery(namespaces []string, labelSelector string, status []string, sort string) url.Values {
+	query := url.Values{}
+	if len(namespaces) > 0 {
+		query.Set("namespace", strings.Join(namespaces, ","))
+	}
+	if labelSelector != "" {
+		query.Set("labelSelector", labelSelector)
+	}
+	if len(status) > 0 {
+		query.Set("status", strings.Join(status, ","))
+	}
+	if sort != "" {
+		query.Set("sort", sort)
+	}
+	return query
+}
+
+// expand fills the path parameters of a route in order, escaping each value
+func expand(route string, values ...string) string {
+	i := 0
+	return pathParamPattern.ReplaceAllStringFunc(route, func(string) string {
+		value := url.PathEscape(values[i])
+		i++
+		return value
+	})
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %v", method, path, err)
+	}
+	return nil
+}
+
+// download copies the body of a GET response to w
+func (c *Client) download(ctx context.Context, path string, query url.Values, w io.Writer) error {
+	resp, err := c.send(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// send sends a request with an optional JSON body. It returns an APIError for non-2xx
+// responses; otherwise the caller must close the response body.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		var apiErr api.Error
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if json.Unmarshal(data, &apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = strings.TrimSpace(string(data))
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: apiErr.Error}
+	}
+	return resp, nil
+}
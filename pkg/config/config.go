@@ -1,42 +1,291 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"hpa-monitor/pkg/logger"
 )
 
-// Config holds application configuration
+// Config holds application configuration.
+//
+// Each field is loaded from, in increasing precedence: defaults, the config file
+// (json key), environment variables (env tag) and command line flags (flag tag).
+// Fields that hold or point at credentials are tagged secret:"true" so they are masked
+// wherever the configuration is printed, logged or diffed.
 type Config struct {
-	Port              string
-	Tolerance         float64
-	WebSocketInterval int    // seconds
-	LogLevel          string // debug, info, warn, error, fatal, panic
+	Port                 string   `json:"port" env:"PORT" flag:"port" usage:"Server port"`
+	GRPCPort             string   `json:"grpcPort" env:"GRPC_PORT" flag:"grpc-port" usage:"gRPC API port, empty to disable"`
+	TLSCertFile          string   `json:"tlsCertFile" env:"TLS_CERT_FILE" flag:"tls-cert-file" usage:"TLS certificate file; with tlsKeyFile the server and gRPC API are served over TLS"`
+	TLSKeyFile           string   `json:"tlsKeyFile" env:"TLS_KEY_FILE" flag:"tls-key-file" usage:"TLS private key file" secret:"true"`
+	TLSClientCAFile      string   `json:"tlsClientCAFile" env:"TLS_CLIENT_CA_FILE" flag:"tls-client-ca-file" usage:"CA bundle that client certificates must be signed by, empty to not require client certificates"`
+	HTTPRedirectPort     string   `json:"httpRedirectPort" env:"HTTP_REDIRECT_PORT" flag:"http-redirect-port" usage:"Plain HTTP port that redirects to HTTPS, empty to disable"`
+	AllowedOrigins       []string `json:"allowedOrigins" env:"ALLOWED_ORIGINS" flag:"allowed-origins" usage:"Comma-separated origins besides the server's own allowed to open WebSockets and make CORS requests, e.g. https://portal.example.com, or * for any"`
+	FrameAncestors       []string `json:"frameAncestors" env:"FRAME_ANCESTORS" flag:"frame-ancestors" usage:"Comma-separated origins allowed to embed the dashboard in a frame, e.g. https://portal.example.com, empty to forbid framing"`
+	BasePath             string   `json:"basePath" env:"BASE_PATH" flag:"base-path" usage:"URL path prefix of every route, e.g. /hpa-monitor, when served under a sub-path"`
+	Tolerance            float64  `json:"tolerance" env:"TOLERANCE" flag:"tolerance" usage:"HPA tolerance (0.0 to 1.0)"`
+	WebSocketInterval    int      `json:"websocketInterval" env:"WEBSOCKET_INTERVAL" flag:"websocket-interval" usage:"WebSocket update interval in seconds"`
+	WebSocketMinInterval int      `json:"websocketMinInterval" env:"WEBSOCKET_MIN_INTERVAL" flag:"websocket-min-interval" usage:"Shortest update interval in seconds a WebSocket client may subscribe with"`
+	WebSocketMaxInterval int      `json:"websocketMaxInterval" env:"WEBSOCKET_MAX_INTERVAL" flag:"websocket-max-interval" usage:"Longest update interval in seconds a WebSocket client may subscribe with"`
+	WebSocketCompression bool     `json:"websocketCompression" env:"WEBSOCKET_COMPRESSION" flag:"websocket-compression" usage:"Offer permessage-deflate compression to WebSocket clients"`
+	LogLevel             string   `json:"logLevel" env:"LOG_LEVEL" flag:"log-level" usage:"Log level: debug, info, warn, error, fatal, panic"`
+	HistoryInterval      int      `json:"historyInterval" env:"HISTORY_INTERVAL" flag:"history-interval" usage:"History sampling interval in seconds"`
+	HistoryRetention     int      `json:"historyRetentionDays" env:"HISTORY_RETENTION_DAYS" flag:"history-retention-days" usage:"Days of history kept for recommendations"`
+	HistoryMaxSamples    int      `json:"historyMaxSamples" env:"HISTORY_MAX_SAMPLES" flag:"history-max-samples" usage:"Samples of history kept across all HPAs, about 135 bytes each; 0 for no limit"`
+	ClusterName          string   `json:"clusterName" env:"CLUSTER_NAME" flag:"cluster-name" usage:"Cluster name reported in scale transitions"`
+	Namespaces           []string `json:"namespaces" env:"NAMESPACES" flag:"namespaces" usage:"Comma-separated namespaces to monitor, empty for all"`
+	RequestTimeout       int      `json:"requestTimeout" env:"REQUEST_TIMEOUT" flag:"request-timeout" usage:"Timeout in seconds for each Kubernetes API call"`
+	ShutdownTimeout      int      `json:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"Seconds to drain connections on shutdown"`
+	RunbookTemplate      string   `json:"runbookTemplate" env:"RUNBOOK_TEMPLATE" flag:"runbook-template" usage:"Go template for event runbook links, e.g. https://runbooks.example.com/hpa/{{.Category}}"`
+	WebDir               string   `json:"webDir" env:"WEB_DIR" flag:"web-dir" usage:"Serve the dashboard from this directory instead of the embedded copy, re-reading it on every request"`
+
+	LeaderElection          bool   `json:"leaderElection" env:"LEADER_ELECTION" flag:"leader-election" usage:"Enable Lease-based leader election"`
+	LeaderElectionNamespace string `json:"leaderElectionNamespace" env:"LEADER_ELECTION_NAMESPACE" flag:"leader-election-namespace" usage:"Namespace of the leader election Lease"`
+	LeaderElectionLease     string `json:"leaderElectionLease" env:"LEADER_ELECTION_LEASE" flag:"leader-election-lease" usage:"Name of the leader election Lease"`
+	PodName                 string `json:"podName" env:"POD_NAME" flag:"pod-name" usage:"Replica identity used for leader election"`
+
+	// Alert rules and receivers are structured, so they are only read from the config file
+	AlertRules     []AlertRule     `json:"alertRules"`
+	AlertReceivers []AlertReceiver `json:"alertReceivers" secret:"true"`
+}
+
+// AlertRule sends a notification to its receivers when a matching HPA alert fires and
+// when it resolves
+type AlertRule struct {
+	Name string `json:"name"`
+	// Namespaces and Reasons limit the rule to HPAs in those namespaces and to alerts whose
+	// condition has one of those reasons; empty matches every one
+	Namespaces []string `json:"namespaces,omitempty"`
+	Reasons    []string `json:"reasons,omitempty"`
+	// For is how long an alert must last before it fires, instead of the HPA's
+	// hpa-monitor.io/alert-max-duration annotation
+	For       string   `json:"for,omitempty"`
+	Receivers []string `json:"receivers"`
+}
+
+// AlertReceiver is a webhook that notifications are posted to as JSON. The URL is set
+// inline or read from a file, such as a mounted Secret, before every notification.
+type AlertReceiver struct {
+	Name           string `json:"name"`
+	WebhookURL     string `json:"webhookURL,omitempty"`
+	WebhookURLFile string `json:"webhookURLFile,omitempty"`
+}
+
+// basePathPattern matches URL path prefixes such as /hpa-monitor or /tools/hpa-monitor/
+var basePathPattern = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)*/?$`)
+
+// validateOrigin checks that origin is a scheme and host such as https://portal.example.com,
+// with a leading *. in the host allowed when wildcardHost is set
+func validateOrigin(origin string, wildcardHost bool) error {
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("must be an origin such as https://portal.example.com, got %q", origin)
+	}
+	if strings.Contains(u.Host, "*") && !(wildcardHost && strings.HasPrefix(u.Host, "*.") && !strings.Contains(u.Host[2:], "*")) {
+		return fmt.Errorf("wildcards are not allowed in %q", origin)
+	}
+	return nil
+}
+
+// validLogLevels are the accepted values for LogLevel
+var validLogLevels = []string{"debug", "info", "warn", "warning", "error", "fatal", "panic"}
+
+// Default returns the configuration used when nothing else is set
+func Default() *Config {
+	return &Config{
+		Port:                 "8080",
+		Tolerance:            0.1,
+		WebSocketInterval:    5,
+		WebSocketMinInterval: 1,
+		WebSocketMaxInterval: 300,
+		WebSocketCompression: true,
+		LogLevel:             "info",
+		HistoryInterval:      60,
+		HistoryRetention:     14,
+		HistoryMaxSamples:    100000,
+		RequestTimeout:       10,
+		ShutdownTimeout:      15,
+
+		LeaderElection:          false,
+		LeaderElectionNamespace: getEnv("POD_NAMESPACE", "default"),
+		LeaderElectionLease:     "hpa-monitor",
+		PodName:                 hostname(),
+	}
+}
+
+// Validate checks every field and returns an error naming each invalid key
+func (c *Config) Validate() error {
+	var errs []error
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("port: must be a number between 1 and 65535, got %q", c.Port))
+	}
+	if c.GRPCPort != "" {
+		if port, err := strconv.Atoi(c.GRPCPort); err != nil || port < 1 || port > 65535 {
+			errs = append(errs, fmt.Errorf("grpcPort: must be a number between 1 and 65535, got %q", c.GRPCPort))
+		} else if c.GRPCPort == c.Port {
+			errs = append(errs, fmt.Errorf("grpcPort: must differ from port %s", c.Port))
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, fmt.Errorf("tlsCertFile, tlsKeyFile: must be set together"))
+	}
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		errs = append(errs, fmt.Errorf("tlsClientCAFile: requires tlsCertFile and tlsKeyFile"))
+	}
+	if c.HTTPRedirectPort != "" {
+		if port, err := strconv.Atoi(c.HTTPRedirectPort); err != nil || port < 1 || port > 65535 {
+			errs = append(errs, fmt.Errorf("httpRedirectPort: must be a number between 1 and 65535, got %q", c.HTTPRedirectPort))
+		} else if c.HTTPRedirectPort == c.Port || c.HTTPRedirectPort == c.GRPCPort {
+			errs = append(errs, fmt.Errorf("httpRedirectPort: must differ from port and grpcPort"))
+		} else if c.TLSCertFile == "" {
+			errs = append(errs, fmt.Errorf("httpRedirectPort: requires tlsCertFile and tlsKeyFile"))
+		}
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin != "*" {
+			if err := validateOrigin(origin, false); err != nil {
+				errs = append(errs, fmt.Errorf("allowedOrigins: %v", err))
+			}
+		}
+	}
+	for _, origin := range c.FrameAncestors {
+		if origin != "'self'" {
+			if err := validateOrigin(origin, true); err != nil {
+				errs = append(errs, fmt.Errorf("frameAncestors: %v", err))
+			}
+		}
+	}
+	if c.BasePath != "" && !basePathPattern.MatchString(c.BasePath) {
+		errs = append(errs, fmt.Errorf("basePath: must be a URL path such as /hpa-monitor, got %q", c.BasePath))
+	}
+	if c.Tolerance < 0 || c.Tolerance > 1 {
+		errs = append(errs, fmt.Errorf("tolerance: must be between 0.0 and 1.0, got %g", c.Tolerance))
+	}
+	if c.WebSocketInterval < 1 {
+		errs = append(errs, fmt.Errorf("websocketInterval: must be at least 1 second, got %d", c.WebSocketInterval))
+	}
+	if c.WebSocketMinInterval < 1 {
+		errs = append(errs, fmt.Errorf("websocketMinInterval: must be at least 1 second, got %d", c.WebSocketMinInterval))
+	}
+	if c.WebSocketMaxInterval < c.WebSocketMinInterval {
+		errs = append(errs, fmt.Errorf("websocketMaxInterval: must be at least websocketMinInterval (%d), got %d", c.WebSocketMinInterval, c.WebSocketMaxInterval))
+	}
+	if !contains(validLogLevels, strings.ToLower(c.LogLevel)) {
+		errs = append(errs, fmt.Errorf("logLevel: must be one of %s, got %q", strings.Join(validLogLevels, ", "), c.LogLevel))
+	}
+	if c.HistoryInterval < 1 {
+		errs = append(errs, fmt.Errorf("historyInterval: must be at least 1 second, got %d", c.HistoryInterval))
+	}
+	if c.HistoryRetention < 1 {
+		errs = append(errs, fmt.Errorf("historyRetentionDays: must be at least 1 day, got %d", c.HistoryRetention))
+	}
+	if c.HistoryMaxSamples < 0 {
+		errs = append(errs, fmt.Errorf("historyMaxSamples: must not be negative, got %d", c.HistoryMaxSamples))
+	}
+	if c.RequestTimeout < 1 {
+		errs = append(errs, fmt.Errorf("requestTimeout: must be at least 1 second, got %d", c.RequestTimeout))
+	}
+	if c.ShutdownTimeout < 1 {
+		errs = append(errs, fmt.Errorf("shutdownTimeout: must be at least 1 second, got %d", c.ShutdownTimeout))
+	}
+	if c.RunbookTemplate != "" {
+		if _, err := template.New("runbook").Parse(c.RunbookTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("runbookTemplate: %v", err))
+		}
+	}
+	if c.WebDir != "" {
+		if info, err := os.Stat(c.WebDir); err != nil || !info.IsDir() {
+			errs = append(errs, fmt.Errorf("webDir: must be an existing directory, got %q", c.WebDir))
+		}
+	}
+	for _, namespace := range c.Namespaces {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("namespaces: invalid namespace %q: %s", namespace, strings.Join(msgs, ", ")))
+		}
+	}
+	errs = append(errs, validateAlerts(c.AlertRules, c.AlertReceivers)...)
+	if c.LeaderElection {
+		if c.LeaderElectionNamespace == "" {
+			errs = append(errs, fmt.Errorf("leaderElectionNamespace: required when leaderElection is enabled"))
+		}
+		if c.LeaderElectionLease == "" {
+			errs = append(errs, fmt.Errorf("leaderElectionLease: required when leaderElection is enabled"))
+		}
+		if c.PodName == "" {
+			errs = append(errs, fmt.Errorf("podName: required when leaderElection is enabled"))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
-// NewConfig creates a new configuration from environment variables
-func NewConfig() *Config {
-	config := &Config{
-		Port:              getEnv("PORT", "8080"),
-		Tolerance:         getFloatEnv("TOLERANCE", 0.1),
-		WebSocketInterval: getIntEnv("WEBSOCKET_INTERVAL", 5),
-		LogLevel:          getEnv("LOG_LEVEL", "info"),
+// validateAlerts checks that rules and receivers are named uniquely, that every rule sends
+// to defined receivers and that every receiver has one webhook URL
+func validateAlerts(rules []AlertRule, receivers []AlertReceiver) []error {
+	var errs []error
+
+	receiverNames := make(map[string]bool, len(receivers))
+	for i, receiver := range receivers {
+		switch {
+		case receiver.Name == "":
+			errs = append(errs, fmt.Errorf("alertReceivers[%d]: name is required", i))
+		case receiverNames[receiver.Name]:
+			errs = append(errs, fmt.Errorf("alertReceivers[%d]: duplicate name %q", i, receiver.Name))
+		}
+		receiverNames[receiver.Name] = true
+
+		if (receiver.WebhookURL == "") == (receiver.WebhookURLFile == "") {
+			errs = append(errs, fmt.Errorf("alertReceivers[%d]: exactly one of webhookURL and webhookURLFile is required", i))
+		} else if receiver.WebhookURL != "" {
+			// The URL may carry a token, so it is not repeated in the error
+			if u, err := url.Parse(receiver.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("alertReceivers[%d]: webhookURL must be an http or https URL", i))
+			}
+		}
 	}
 
-	// Initialize logger with configured log level
-	logger.InitLogger(config.LogLevel)
+	ruleNames := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		switch {
+		case rule.Name == "":
+			errs = append(errs, fmt.Errorf("alertRules[%d]: name is required", i))
+		case ruleNames[rule.Name]:
+			errs = append(errs, fmt.Errorf("alertRules[%d]: duplicate name %q", i, rule.Name))
+		}
+		ruleNames[rule.Name] = true
 
-	// Log configuration
+		if rule.For != "" {
+			if d, err := time.ParseDuration(rule.For); err != nil || d < 0 {
+				errs = append(errs, fmt.Errorf("alertRules[%d]: for must be a duration such as 10m, got %q", i, rule.For))
+			}
+		}
+		if len(rule.Receivers) == 0 {
+			errs = append(errs, fmt.Errorf("alertRules[%d]: at least one receiver is required", i))
+		}
+		for _, name := range rule.Receivers {
+			if !receiverNames[name] {
+				errs = append(errs, fmt.Errorf("alertRules[%d]: unknown receiver %q", i, name))
+			}
+		}
+	}
+	return errs
+}
+
+// LogConfig logs the effective configuration with secrets redacted
+func (c *Config) LogConfig() {
 	log := logger.GetLogger()
-	log.WithFields(logger.Fields{
-		"port":              config.Port,
-		"tolerance":         config.Tolerance,
-		"websocket_interval": config.WebSocketInterval,
-		"log_level":         config.LogLevel,
-	}).Info("Configuration loaded")
-
-	return config
+	log.WithFields(logger.Fields(c.Redacted())).Info("Configuration loaded")
 }
 
 // getEnv gets an environment variable with a default value
@@ -47,22 +296,20 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-// getFloatEnv gets a float environment variable with a default value
-func getFloatEnv(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
-		if f, err := strconv.ParseFloat(value, 64); err == nil {
-			return f
-		}
+// hostname returns the host name, used as the default pod identity
+func hostname() string {
+	if name, err := os.Hostname(); err == nil {
+		return name
 	}
-	return defaultValue
+	return "hpa-monitor"
 }
 
-// getIntEnv gets an int environment variable with a default value
-func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if i, err := strconv.Atoi(value); err == nil {
-			return i
+// contains reports whether values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
 		}
 	}
-	return defaultValue
-}
\ No newline at end of file
+	return false
+}
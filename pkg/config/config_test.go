@@ -0,0 +1,101 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// secretKeys are the configuration keys masked when printed, logged or diffed, each an
+// explicit decision: the TLS key path points at the server's private key, and webhook
+// URLs of alert receivers usually carry a token
+var secretKeys = map[string]func(c *Config, value string){
+	"tlsKeyFile": func(c *Config, value string) { c.TLSKeyFile = "/etc/tls/" + value },
+	"alertReceivers": func(c *Config, value string) {
+		c.AlertReceivers = []AlertReceiver{{Name: "oncall", WebhookURL: "https://hooks.example.com/" + value}}
+	},
+}
+
+func TestSecretFields(t *testing.T) {
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		_, want := secretKeys[key]
+		if got := field.Tag.Get("secret") == "true"; got != want {
+			t.Errorf("%s tagged secret %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.TLSCertFile = "/etc/tls/tls.crt"
+	for key := range secretKeys {
+		if got := cfg.Redacted()[key]; !reflect.ValueOf(got).IsZero() {
+			t.Errorf("unset %s printed as %v, want its zero value", key, got)
+		}
+	}
+
+	for _, set := range secretKeys {
+		set(cfg, "one")
+	}
+	values := cfg.Redacted()
+	for key := range secretKeys {
+		if got := values[key]; got != redactedValue {
+			t.Errorf("%s printed as %v, want %s", key, got, redactedValue)
+		}
+	}
+	if got := values["tlsCertFile"]; got != cfg.TLSCertFile {
+		t.Errorf("tlsCertFile printed as %v, want %s", got, cfg.TLSCertFile)
+	}
+}
+
+func TestDiffReportsChangedSecrets(t *testing.T) {
+	for key, set := range secretKeys {
+		old := Default()
+		set(old, "one")
+		next := *old
+		set(&next, "one")
+		if changes := Diff(old, &next); len(changes) != 0 {
+			t.Errorf("%s: changes %v for the same value, want none", key, changes)
+		}
+
+		// Changing one secret value for another is reported, without either value
+		set(&next, "two")
+		changes := Diff(old, &next)
+		if len(changes) != 1 || changes[0].Key != key {
+			t.Fatalf("%s: changes %v, want only %s", key, changes, key)
+		}
+		if changes[0].Old != redactedValue || changes[0].New != redactedValue {
+			t.Errorf("%s change %v -> %v, want both %s", key, changes[0].Old, changes[0].New, redactedValue)
+		}
+	}
+}
+
+func TestValidateAlerts(t *testing.T) {
+	receivers := []AlertReceiver{
+		{Name: "oncall", WebhookURL: "https://hooks.example.com/oncall"},
+		{Name: "team-shop", WebhookURLFile: "/etc/hpa-monitor/webhooks/team-shop"},
+	}
+	tests := []struct {
+		name      string
+		rules     []AlertRule
+		receivers []AlertReceiver
+		errors    int
+	}{
+		{"valid", []AlertRule{{Name: "stuck", For: "10m", Receivers: []string{"oncall", "team-shop"}}}, receivers, 0},
+		{"unknown receiver", []AlertRule{{Name: "stuck", Receivers: []string{"pager"}}}, receivers, 1},
+		{"no receivers", []AlertRule{{Name: "stuck"}}, receivers, 1},
+		{"invalid for", []AlertRule{{Name: "stuck", For: "ten minutes", Receivers: []string{"oncall"}}}, receivers, 1},
+		{"duplicate rule", []AlertRule{{Name: "stuck", Receivers: []string{"oncall"}}, {Name: "stuck", Receivers: []string{"oncall"}}}, receivers, 1},
+		{"both URLs", nil, []AlertReceiver{{Name: "oncall", WebhookURL: "https://hooks.example.com", WebhookURLFile: "/url"}}, 1},
+		{"no URL", nil, []AlertReceiver{{Name: "oncall"}}, 1},
+		{"invalid URL", nil, []AlertReceiver{{Name: "oncall", WebhookURL: "hooks.example.com/oncall"}}, 1},
+	}
+	for _, test := range tests {
+		if errs := validateAlerts(test.rules, test.receivers); len(errs) != test.errors {
+			t.Errorf("%s: errors %v, want %d", test.name, errs, test.errors)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// redactedValue replaces secret values when printing configuration
+const redactedValue = "<redacted>"
+
+// Loader loads configuration from a file, environment variables and flags
+type Loader struct {
+	configFile string
+	flagValues map[string]string
+}
+
+// NewLoader registers --config and one flag per configuration key on flags
+func NewLoader(flags *flag.FlagSet) *Loader {
+	l := &Loader{flagValues: make(map[string]string)}
+
+	flags.StringVar(&l.configFile, "config", os.Getenv("CONFIG_FILE"), "Path to a YAML or JSON config file (env CONFIG_FILE)")
+
+	defaults := reflect.ValueOf(Default()).Elem()
+	t := defaults.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("flag")
+		if name == "" {
+			continue
+		}
+		usage := fmt.Sprintf("%s (env %s, default %v)", field.Tag.Get("usage"), field.Tag.Get("env"), formatValue(defaults.Field(i)))
+		flags.Func(name, usage, func(value string) error {
+			l.flagValues[name] = value
+			return nil
+		})
+	}
+	return l
+}
+
+// ConfigFile returns the config file path, if any
+func (l *Loader) ConfigFile() string {
+	return l.configFile
+}
+
+// Load builds and validates the configuration; call after the flag set is parsed
+func (l *Loader) Load() (*Config, error) {
+	cfg := Default()
+
+	if l.configFile != "" {
+		if err := loadFile(cfg, l.configFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyOverrides(cfg, "env", os.Getenv); err != nil {
+		return nil, err
+	}
+	if err := applyOverrides(cfg, "flag", func(name string) string { return l.flagValues[name] }); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%v", err)
+	}
+	return cfg, nil
+}
+
+// loadFile merges a YAML or JSON file into cfg, rejecting unknown keys
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return fmt.Errorf("config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// applyOverrides sets fields whose tag resolves to a non-empty value through lookup
+func applyOverrides(cfg *Config, tag string, lookup func(string) string) error {
+	var errs []error
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get(tag)
+		if name == "" {
+			continue
+		}
+		raw := lookup(name)
+		if raw == "" {
+			continue
+		}
+		if err := setValue(v.Field(i), raw); err != nil {
+			source := name
+			if tag == "flag" {
+				source = "--" + name
+			}
+			errs = append(errs, fmt.Errorf("%s (%s): %v", source, t.Field(i).Tag.Get("json"), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setValue parses raw into a field according to its type
+func setValue(field reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		field.SetInt(i)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", field.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// Redacted returns the configuration keyed by config file key, with secret fields masked
+func (c *Config) Redacted() map[string]interface{} {
+	return c.values(true)
+}
+
+// values returns the configuration keyed by config file key, masking secret fields
+// when redact is set
+func (c *Config) values(redact bool) map[string]interface{} {
+	result := make(map[string]interface{})
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		if redact && field.Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+			result[key] = redactedValue
+			continue
+		}
+		result[key] = v.Field(i).Interface()
+	}
+	return result
+}
+
+// formatValue renders a field value for usage and output
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+
+	"hpa-monitor/pkg/logger"
+)
+
+// Change describes one configuration key that differs between two configurations
+type Change struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Diff returns the keys that differ between old and new, with secrets redacted. Values are
+// compared before redaction, so a change between two secrets is still reported.
+func Diff(old, new *Config) []Change {
+	oldValues, newValues := old.values(false), new.values(false)
+	oldRedacted, newRedacted := old.Redacted(), new.Redacted()
+
+	var changes []Change
+	for key, newValue := range newValues {
+		if !reflect.DeepEqual(oldValues[key], newValue) {
+			changes = append(changes, Change{Key: key, Old: oldRedacted[key], New: newRedacted[key]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// Watch polls the config file and calls apply with each valid configuration that differs
+// from the previous one. Invalid configurations are logged and the previous one is kept.
+func (l *Loader) Watch(ctx context.Context, current *Config, interval time.Duration, apply func(cfg *Config, changes []Change)) {
+	log := logger.GetLogger()
+	if l.configFile == "" {
+		return
+	}
+
+	log.WithFields(logger.Fields{
+		"path":     l.configFile,
+		"interval": interval.String(),
+	}).Info("Watching config file for changes")
+
+	lastHash := fileHash(l.configFile)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Content hashing also catches ConfigMap updates, which swap a symlink
+			hash := fileHash(l.configFile)
+			if hash == lastHash {
+				continue
+			}
+			lastHash = hash
+
+			next, err := l.Load()
+			if err != nil {
+				log.WithError(err).Error("Rejected config reload, keeping previous configuration")
+				continue
+			}
+
+			changes := Diff(current, next)
+			if len(changes) == 0 {
+				continue
+			}
+			for _, change := range changes {
+				log.WithFields(logger.Fields{
+					"key": change.Key,
+					"old": change.Old,
+					"new": change.New,
+				}).Info("Configuration changed")
+			}
+
+			apply(next, changes)
+			current = next
+		}
+	}
+}
+
+// fileHash returns the SHA-256 of a file, or an empty string when it cannot be read
+func fileHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return string(sum[:])
+}
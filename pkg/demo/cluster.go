@@ -0,0 +1,299 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/simulate"
+)
+
+const (
+	// SyncPeriod matches the HPA controller's default sync period
+	SyncPeriod = 15 * time.Second
+	// eventTTL matches the API server's default event retention
+	eventTTL = time.Hour
+)
+
+// workload tracks a scenario and its controller state
+type workload struct {
+	scenario   Scenario
+	controller *simulate.Controller
+}
+
+// Cluster is an in-process fake cluster whose HPAs follow scripted load curves
+type Cluster struct {
+	client    *fake.Clientset
+	workloads []*workload
+	start     time.Time
+}
+
+// NewCluster creates a fake cluster seeded with the given scenarios at start
+func NewCluster(scenarios []Scenario, start time.Time) (*Cluster, error) {
+	ctx := context.Background()
+	c := &Cluster{
+		client: fake.NewSimpleClientset(),
+		start:  start,
+	}
+
+	for _, scenario := range scenarios {
+		hpa := scenario.HPA(start)
+		controller, err := simulate.NewController(hpa, simulate.DefaultTolerance)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s/%s: %v", scenario.Namespace, scenario.Name, err)
+		}
+
+		if _, err := c.client.AutoscalingV2().HorizontalPodAutoscalers(scenario.Namespace).Create(ctx, hpa, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+		if _, err := c.client.AppsV1().Deployments(scenario.Namespace).Create(ctx, scenario.Deployment(start), metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+		c.workloads = append(c.workloads, &workload{scenario: scenario, controller: controller})
+	}
+
+	c.Tick(start)
+	return c, nil
+}
+
+// Client returns the fake Kubernetes client
+func (c *Cluster) Client() kubernetes.Interface {
+	return c.client
+}
+
+// Run advances the scenarios every sync period until the context is cancelled
+func (c *Cluster) Run(ctx context.Context) {
+	log := logger.GetLogger()
+	log.WithField("hpa_count", len(c.workloads)).Info("Demo cluster started")
+
+	ticker := time.NewTicker(SyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Demo cluster stopped")
+			return
+		case now := <-ticker.C:
+			c.Tick(now)
+		}
+	}
+}
+
+// Tick performs one HPA controller sync for every scenario at the given time
+func (c *Cluster) Tick(now time.Time) {
+	log := logger.GetLogger()
+	ctx := context.Background()
+
+	for _, w := range c.workloads {
+		if err := c.sync(ctx, w, now); err != nil {
+			log.WithFields(logger.Fields{
+				"namespace": w.scenario.Namespace,
+				"name":      w.scenario.Name,
+			}).WithError(err).Error("Failed to advance demo scenario")
+		}
+	}
+	c.expireEvents(ctx, now)
+}
+
+// sync updates one HPA's status, its scale target and events
+func (c *Cluster) sync(ctx context.Context, w *workload, now time.Time) error {
+	s := w.scenario
+	hpa, err := c.client.AutoscalingV2().HorizontalPodAutoscalers(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Pods requested in the previous sync are ready now
+	current := hpa.Status.DesiredReplicas
+	if current < 1 {
+		current = s.MinReplicas
+	}
+	hpa.Status.CurrentReplicas = current
+
+	if s.MetricsMissing {
+		hpa.Status.DesiredReplicas = current
+		hpa.Status.CurrentMetrics = nil
+		setCondition(hpa, autoscalingv2.AbleToScale, corev1.ConditionTrue, "SucceededGetScale",
+			"the HPA controller was able to get the target's current scale", now)
+		setCondition(hpa, autoscalingv2.ScalingActive, corev1.ConditionFalse, "FailedGetResourceMetric",
+			"the HPA was unable to compute the replica count: failed to get cpu utilization: unable to get metrics for resource cpu: no metrics returned from resource metrics API", now)
+		c.recordEvent(ctx, hpa, corev1.EventTypeWarning, "FailedGetResourceMetric",
+			"failed to get cpu utilization: unable to get metrics for resource cpu: no metrics returned from resource metrics API", now)
+		c.recordEvent(ctx, hpa, corev1.EventTypeWarning, "FailedComputeMetricsReplicas",
+			"invalid metrics (1 invalid out of 1), first error is: failed to get cpu resource metric value: failed to get cpu utilization: unable to get metrics for resource cpu: no metrics returned from resource metrics API", now)
+		_, err := c.client.AutoscalingV2().HorizontalPodAutoscalers(s.Namespace).Update(ctx, hpa, metav1.UpdateOptions{})
+		return err
+	}
+
+	elapsed := now.Sub(c.start)
+	utilization := s.Load(elapsed) / float64(current)
+	step := w.controller.Sync(int(elapsed.Seconds()), current, utilization)
+
+	currentUtilization := int32(math.Round(utilization))
+	hpa.Status.DesiredReplicas = step.DesiredReplicas
+	hpa.Status.CurrentMetrics = []autoscalingv2.MetricStatus{{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricStatus{
+			Name:    corev1.ResourceCPU,
+			Current: autoscalingv2.MetricValueStatus{AverageUtilization: &currentUtilization},
+		},
+	}}
+
+	setCondition(hpa, autoscalingv2.ScalingActive, corev1.ConditionTrue, "ValidMetricFound",
+		"the HPA was able to successfully calculate a replica count from cpu resource utilization (percentage of request)", now)
+	setScalingConditions(hpa, step, now)
+
+	if step.DesiredReplicas != current {
+		lastScale := metav1.NewTime(now)
+		hpa.Status.LastScaleTime = &lastScale
+
+		reason := "cpu resource utilization (percentage of request) above target"
+		if step.DesiredReplicas < current {
+			reason = "All metrics below target"
+		}
+		c.recordEvent(ctx, hpa, corev1.EventTypeNormal, "SuccessfulRescale",
+			fmt.Sprintf("New size: %d; reason: %s", step.DesiredReplicas, reason), now)
+
+		if err := c.scaleDeployment(ctx, s, step.DesiredReplicas); err != nil {
+			return err
+		}
+	}
+
+	_, err = c.client.AutoscalingV2().HorizontalPodAutoscalers(s.Namespace).Update(ctx, hpa, metav1.UpdateOptions{})
+	return err
+}
+
+// setScalingConditions sets AbleToScale and ScalingLimited from a controller step
+func setScalingConditions(hpa *autoscalingv2.HorizontalPodAutoscaler, step simulate.Step, now time.Time) {
+	switch {
+	case step.Reason == "Stabilized" && step.StabilizedReplicas > step.ProposedReplicas:
+		setCondition(hpa, autoscalingv2.AbleToScale, corev1.ConditionTrue, "ScaleDownStabilized",
+			"recent recommendations were higher than current one, applying the highest recent recommendation", now)
+	case step.DesiredReplicas != step.CurrentReplicas:
+		setCondition(hpa, autoscalingv2.AbleToScale, corev1.ConditionTrue, "SucceededRescale",
+			fmt.Sprintf("the HPA controller was able to update the target scale to %d", step.DesiredReplicas), now)
+	default:
+		setCondition(hpa, autoscalingv2.AbleToScale, corev1.ConditionTrue, "ReadyForNewScale",
+			"recommended size matches current size", now)
+	}
+
+	switch step.Reason {
+	case "TooManyReplicas":
+		setCondition(hpa, autoscalingv2.ScalingLimited, corev1.ConditionTrue, step.Reason,
+			"the desired replica count is more than the maximum replica count", now)
+	case "TooFewReplicas":
+		setCondition(hpa, autoscalingv2.ScalingLimited, corev1.ConditionTrue, step.Reason,
+			"the desired replica count is less than the minimum replica count", now)
+	case "ScaleUpLimit":
+		setCondition(hpa, autoscalingv2.ScalingLimited, corev1.ConditionTrue, step.Reason,
+			"the desired replica count is increasing faster than the maximum scale rate", now)
+	case "ScaleDownLimit":
+		setCondition(hpa, autoscalingv2.ScalingLimited, corev1.ConditionTrue, step.Reason,
+			"the desired replica count is decreasing faster than the maximum scale rate", now)
+	default:
+		setCondition(hpa, autoscalingv2.ScalingLimited, corev1.ConditionFalse, "DesiredWithinRange",
+			"the desired count is within the acceptable range", now)
+	}
+}
+
+// setCondition updates a status condition, keeping the transition time when the status is unchanged
+func setCondition(hpa *autoscalingv2.HorizontalPodAutoscaler, conditionType autoscalingv2.HorizontalPodAutoscalerConditionType,
+	status corev1.ConditionStatus, reason, message string, now time.Time) {
+	for i := range hpa.Status.Conditions {
+		existing := &hpa.Status.Conditions[i]
+		if existing.Type != conditionType {
+			continue
+		}
+		if existing.Status != status {
+			existing.LastTransitionTime = metav1.NewTime(now)
+		}
+		existing.Status, existing.Reason, existing.Message = status, reason, message
+		return
+	}
+
+	hpa.Status.Conditions = append(hpa.Status.Conditions, autoscalingv2.HorizontalPodAutoscalerCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(now),
+	})
+}
+
+// scaleDeployment sets the scale target's replicas
+func (c *Cluster) scaleDeployment(ctx context.Context, s Scenario, replicas int32) error {
+	deployment, err := c.client.AppsV1().Deployments(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	deployment.Spec.Replicas = &replicas
+	deployment.Status.Replicas = replicas
+	deployment.Status.ReadyReplicas = replicas
+	_, err = c.client.AppsV1().Deployments(s.Namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+	return err
+}
+
+// recordEvent creates an event or bumps the count of an identical one, like the event recorder does
+func (c *Cluster) recordEvent(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, eventType, reason, message string, now time.Time) {
+	log := logger.GetLogger()
+	events := c.client.CoreV1().Events(hpa.Namespace)
+
+	hash := fnv.New32a()
+	hash.Write([]byte(reason + message))
+	name := fmt.Sprintf("%s.%x", hpa.Name, hash.Sum32())
+
+	event, err := events.Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		event.Count++
+		event.LastTimestamp = metav1.NewTime(now)
+		_, err = events.Update(ctx, event, metav1.UpdateOptions{})
+	} else if apierrors.IsNotFound(err) {
+		_, err = events.Create(ctx, &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: hpa.Namespace},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: "autoscaling/v2",
+				Kind:       "HorizontalPodAutoscaler",
+				Name:       hpa.Name,
+				Namespace:  hpa.Namespace,
+			},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+			Count:          1,
+			FirstTimestamp: metav1.NewTime(now),
+			LastTimestamp:  metav1.NewTime(now),
+			Source:         corev1.EventSource{Component: "horizontal-pod-autoscaler"},
+		}, metav1.CreateOptions{})
+	}
+	if err != nil {
+		log.WithFields(logger.Fields{
+			"namespace": hpa.Namespace,
+			"name":      hpa.Name,
+			"reason":    reason,
+		}).WithError(err).Error("Failed to record demo event")
+	}
+}
+
+// expireEvents deletes events older than the event TTL
+func (c *Cluster) expireEvents(ctx context.Context, now time.Time) {
+	events, err := c.client.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	for _, event := range events.Items {
+		if now.Sub(event.LastTimestamp.Time) > eventTTL {
+			c.client.CoreV1().Events(event.Namespace).Delete(ctx, event.Name, metav1.DeleteOptions{})
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package demo_test
+
+import (
+	"testing"
+	"time"
+
+	"hpa-monitor/pkg/demo"
+	"hpa-monitor/pkg/monitor"
+)
+
+// observed collects what the monitor reported for one HPA over a demo run
+type observed struct {
+	last        monitor.HPAStatus
+	maxReplicas int32
+	categories  map[string]bool
+	conditions  map[string]bool
+	syncs       int
+	readySyncs  int
+}
+
+// runScenario drives a single scenario for the given duration and returns
+// what the monitor reported for its HPA at every sync
+func runScenario(t *testing.T, scenario demo.Scenario, duration time.Duration) observed {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster, err := demo.NewCluster([]demo.Scenario{scenario}, start)
+	if err != nil {
+		t.Fatalf("creating cluster: %v", err)
+	}
+	now := start
+	hpaMonitor := monitor.NewHPAMonitor(cluster.Client())
+	hpaMonitor.SetClock(func() time.Time { return now })
+
+	seen := observed{categories: map[string]bool{}, conditions: map[string]bool{}}
+	for ; !now.After(start.Add(duration)); now = now.Add(demo.SyncPeriod) {
+		cluster.Tick(now)
+		statuses, err := hpaMonitor.GetHPAStatus(t.Context())
+		if err != nil {
+			t.Fatalf("getting status at %s: %v", now.Sub(start), err)
+		}
+		if len(statuses) != 1 {
+			t.Fatalf("got %d HPAs at %s, want 1", len(statuses), now.Sub(start))
+		}
+		status := statuses[0]
+		seen.last = status
+		seen.maxReplicas = max(seen.maxReplicas, status.CurrentReplicas)
+		seen.syncs++
+		if status.Ready {
+			seen.readySyncs++
+		}
+		for _, event := range status.Events {
+			seen.categories[event.Category] = true
+		}
+		for _, condition := range status.Conditions {
+			if condition.Status == "True" {
+				seen.conditions[condition.Type+"/"+condition.Reason] = true
+			}
+		}
+	}
+	return seen
+}
+
+func scenario(t *testing.T, namespace, name string) demo.Scenario {
+	t.Helper()
+	for _, s := range demo.Scenarios() {
+		if s.Namespace == namespace && s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("no scenario %s/%s", namespace, name)
+	return demo.Scenario{}
+}
+
+func TestScenarios(t *testing.T) {
+	t.Run("storefront", func(t *testing.T) {
+		seen := runScenario(t, scenario(t, "shop", "storefront"), 15*time.Minute)
+		if seen.readySyncs != seen.syncs {
+			t.Errorf("ready in %d of %d syncs, want ready throughout", seen.readySyncs, seen.syncs)
+		}
+		if !seen.categories[monitor.CategoryRescaleUp] {
+			t.Errorf("categories %v, want %s as load rises", seen.categories, monitor.CategoryRescaleUp)
+		}
+		if seen.maxReplicas >= seen.last.MaxReplicas {
+			t.Errorf("reached %d replicas, want below maxReplicas %d", seen.maxReplicas, seen.last.MaxReplicas)
+		}
+	})
+
+	t.Run("checkout", func(t *testing.T) {
+		seen := runScenario(t, scenario(t, "shop", "checkout"), 12*time.Minute)
+		if seen.maxReplicas != seen.last.MaxReplicas {
+			t.Errorf("reached %d replicas, want maxReplicas %d during the spike", seen.maxReplicas, seen.last.MaxReplicas)
+		}
+		if !seen.conditions["ScalingLimited/TooManyReplicas"] {
+			t.Errorf("conditions %v, want ScalingLimited/TooManyReplicas during the spike", seen.conditions)
+		}
+		if !seen.categories[monitor.CategoryRescaleUp] {
+			t.Errorf("categories %v, want %s", seen.categories, monitor.CategoryRescaleUp)
+		}
+		if seen.last.Tolerance != 0.05 || seen.last.ToleranceSource != monitor.ToleranceSourceAnnotation {
+			t.Errorf("tolerance %v from %s, want 0.05 from %s", seen.last.Tolerance, seen.last.ToleranceSource, monitor.ToleranceSourceAnnotation)
+		}
+		if seen.last.Owner != "team-checkout" {
+			t.Errorf("owner %q, want team-checkout", seen.last.Owner)
+		}
+	})
+
+	t.Run("ledger", func(t *testing.T) {
+		seen := runScenario(t, scenario(t, "payments", "ledger"), 10*time.Minute)
+		if !seen.categories[monitor.CategoryRescaleUp] || !seen.categories[monitor.CategoryRescaleDown] {
+			t.Errorf("categories %v, want both %s and %s from flapping", seen.categories, monitor.CategoryRescaleUp, monitor.CategoryRescaleDown)
+		}
+	})
+
+	t.Run("report-worker", func(t *testing.T) {
+		seen := runScenario(t, scenario(t, "batch", "report-worker"), 5*time.Minute)
+		if seen.readySyncs != 0 {
+			t.Errorf("ready in %d of %d syncs, want never ready without metrics", seen.readySyncs, seen.syncs)
+		}
+		if !seen.categories[monitor.CategoryMetricsServerUnavailable] {
+			t.Errorf("categories %v, want %s", seen.categories, monitor.CategoryMetricsServerUnavailable)
+		}
+		if seen.categories[monitor.CategoryRescaleUp] || seen.categories[monitor.CategoryRescaleDown] {
+			t.Errorf("categories %v, want no rescales without metrics", seen.categories)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package demo
+
+import (
+	"math"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadCurve returns the total demand at a point in the scenario, in utilization percent summed over pods
+type LoadCurve func(elapsed time.Duration) float64
+
+// Scenario is a synthetic HPA whose metric follows a scripted load curve
+type Scenario struct {
+	Name          string
+	Namespace     string
+	Description   string
+	MinReplicas   int32
+	MaxReplicas   int32
+	TargetPercent int32
+	// MetricsMissing simulates an unavailable metrics API for this HPA
+	MetricsMissing bool
+	// ScaleDownStabilization overrides the default scale down window when set
+	ScaleDownStabilization *int32
+	// Annotations are set on the HPA, e.g. hpa-monitor.io settings
+	Annotations map[string]string
+	Load        LoadCurve
+}
+
+// Scenarios returns the built-in demo fixtures
+func Scenarios() []Scenario {
+	noStabilization := int32(0)
+
+	return []Scenario{
+		{
+			Name:          "storefront",
+			Namespace:     "shop",
+			Description:   "Diurnal traffic compressed into a 30 minute day",
+			MinReplicas:   2,
+			MaxReplicas:   12,
+			TargetPercent: 60,
+			Load:          Diurnal(120, 600, 30*time.Minute),
+		},
+		{
+			Name:          "checkout",
+			Namespace:     "shop",
+			Description:   "Flash-sale spike every 10 minutes that hits maxReplicas",
+			MinReplicas:   3,
+			MaxReplicas:   8,
+			TargetPercent: 70,
+			Annotations: map[string]string{
+				"hpa-monitor.io/tolerance": "0.05",
+				"hpa-monitor.io/owner":     "team-checkout",
+				"hpa-monitor.io/runbook":   "https://runbooks.example.com/shop/checkout",
+			},
+			Load: Spike(150, 900, 10*time.Minute, 2*time.Minute),
+		},
+		{
+			Name:                   "ledger",
+			Namespace:              "payments",
+			Description:            "Load oscillating around the target with no scale down stabilization",
+			MinReplicas:            2,
+			MaxReplicas:            10,
+			TargetPercent:          50,
+			ScaleDownStabilization: &noStabilization,
+			Load:                   Flapping(200, 90, 45*time.Second),
+		},
+		{
+			Name:           "report-worker",
+			Namespace:      "batch",
+			Description:    "Metrics API returns no data for this workload",
+			MinReplicas:    1,
+			MaxReplicas:    5,
+			TargetPercent:  75,
+			MetricsMissing: true,
+			Load:           Constant(60),
+		},
+	}
+}
+
+// Diurnal is a sinusoidal load between low and high with the given period
+func Diurnal(low, high float64, period time.Duration) LoadCurve {
+	return func(elapsed time.Duration) float64 {
+		phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+		return low + (high-low)*(1-math.Cos(phase))/2
+	}
+}
+
+// Spike is a flat baseline with a burst of length duration every period
+func Spike(baseline, peak float64, period, duration time.Duration) LoadCurve {
+	return func(elapsed time.Duration) float64 {
+		if elapsed%period >= period-duration {
+			return peak
+		}
+		return baseline
+	}
+}
+
+// Flapping alternates above and below center by amplitude every half period
+func Flapping(center, amplitude float64, halfPeriod time.Duration) LoadCurve {
+	return func(elapsed time.Duration) float64 {
+		if (elapsed/halfPeriod)%2 == 0 {
+			return center + amplitude
+		}
+		return center - amplitude
+	}
+}
+
+// Constant is a flat load
+func Constant(value float64) LoadCurve {
+	return func(time.Duration) float64 {
+		return value
+	}
+}
+
+// HPA returns the HorizontalPodAutoscaler fixture for the scenario
+func (s Scenario) HPA(created time.Time) *autoscalingv2.HorizontalPodAutoscaler {
+	minReplicas := s.MinReplicas
+	targetPercent := s.TargetPercent
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              s.Name,
+			Namespace:         s.Namespace,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{"app": s.Name, "hpa-monitor.io/demo": "true"},
+			Annotations:       s.Annotations,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       s.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: s.MaxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: &targetPercent,
+					},
+				},
+			}},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: s.MinReplicas,
+			DesiredReplicas: s.MinReplicas,
+		},
+	}
+
+	if s.ScaleDownStabilization != nil {
+		hpa.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleDown: &autoscalingv2.HPAScalingRules{
+				StabilizationWindowSeconds: s.ScaleDownStabilization,
+			},
+		}
+	}
+	return hpa
+}
+
+// Deployment returns the scale target fixture for the scenario
+func (s Scenario) Deployment(created time.Time) *appsv1.Deployment {
+	replicas := s.MinReplicas
+	labels := map[string]string{"app": s.Name}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              s.Name,
+			Namespace:         s.Namespace,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: s.Name, Image: "registry.k8s.io/pause:3.9"}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:      s.MinReplicas,
+			ReadyReplicas: s.MinReplicas,
+		},
+	}
+}
@@ -0,0 +1,242 @@
+// Package export writes HPA statuses, and optionally their history summaries, as
+// CSV, NDJSON or XLSX reports for spreadsheets and capacity planning.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/monitor"
+)
+
+// Export formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+// contentTypes are the MIME types of each format
+var contentTypes = map[string]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Row is one exported HPA
+type Row struct {
+	Namespace       string           `json:"namespace"`
+	Name            string           `json:"name"`
+	MinReplicas     int32            `json:"minReplicas"`
+	MaxReplicas     int32            `json:"maxReplicas"`
+	CurrentReplicas int32            `json:"currentReplicas"`
+	DesiredReplicas int32            `json:"desiredReplicas"`
+	Metric          string           `json:"metric"`
+	MetricCurrent   *string          `json:"metricCurrent"`
+	MetricTarget    *string          `json:"metricTarget"`
+	Ratio           *float64         `json:"ratio"`
+	Tolerance       float64          `json:"tolerance"`
+	Ready           bool             `json:"ready"`
+	DerivedStatus   string           `json:"derivedStatus"`
+	Owner           string           `json:"owner,omitempty"`
+	LastScaleTime   *string          `json:"lastScaleTime"`
+	History         *history.Summary `json:"history,omitempty"`
+}
+
+// column is one CSV and XLSX column; numeric columns are written as numbers in XLSX
+type column struct {
+	header  string
+	numeric bool
+	value   func(Row) string
+}
+
+// statusColumns are written for every row
+var statusColumns = []column{
+	{"namespace", false, func(r Row) string { return r.Namespace }},
+	{"name", false, func(r Row) string { return r.Name }},
+	{"minReplicas", true, func(r Row) string { return formatInt(r.MinReplicas) }},
+	{"maxReplicas", true, func(r Row) string { return formatInt(r.MaxReplicas) }},
+	{"currentReplicas", true, func(r Row) string { return formatInt(r.CurrentReplicas) }},
+	{"desiredReplicas", true, func(r Row) string { return formatInt(r.DesiredReplicas) }},
+	{"metric", false, func(r Row) string { return r.Metric }},
+	{"metricCurrent", false, func(r Row) string { return formatString(r.MetricCurrent) }},
+	{"metricTarget", false, func(r Row) string { return formatString(r.MetricTarget) }},
+	{"ratio", true, func(r Row) string {
+		if r.Ratio == nil {
+			return ""
+		}
+		return formatFloat(*r.Ratio)
+	}},
+	{"tolerance", true, func(r Row) string { return formatFloat(r.Tolerance) }},
+	{"ready", false, func(r Row) string { return strconv.FormatBool(r.Ready) }},
+	{"derivedStatus", false, func(r Row) string { return r.DerivedStatus }},
+	{"owner", false, func(r Row) string { return r.Owner }},
+	{"lastScaleTime", false, func(r Row) string { return formatString(r.LastScaleTime) }},
+}
+
+// historyColumns are added when the export includes history; they are empty for
+// HPAs without samples in the window
+var historyColumns = []column{
+	{"historyFrom", false, historyValue(func(s *history.Summary) string { return s.From.UTC().Format(time.RFC3339) })},
+	{"historyTo", false, historyValue(func(s *history.Summary) string { return s.To.UTC().Format(time.RFC3339) })},
+	{"samples", true, historyValue(func(s *history.Summary) string { return strconv.Itoa(s.Samples) })},
+	{"avgReplicas", true, historyValue(func(s *history.Summary) string { return formatFloat(s.AvgReplicas) })},
+	{"p95Replicas", true, historyValue(func(s *history.Summary) string { return formatFloat(s.P95Replicas) })},
+	{"peakReplicas", true, historyValue(func(s *history.Summary) string { return formatInt(s.PeakReplicas) })},
+	{"timeAtMaxSeconds", true, historyValue(func(s *history.Summary) string { return formatFloat(s.TimeAtMax) })},
+	{"timeOutOfToleranceSeconds", true, historyValue(func(s *history.Summary) string { return formatFloat(s.TimeOutOfTolerance) })},
+}
+
+// ContentType returns the MIME type of a format, or an error for unknown formats
+func ContentType(format string) (string, error) {
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return "", fmt.Errorf("unknown format %q: must be %s, %s or %s", format, FormatCSV, FormatNDJSON, FormatXLSX)
+	}
+	return contentType, nil
+}
+
+// NewRows converts HPA statuses to rows. When store is set, each row carries the
+// summary of the HPA's samples since the given time.
+func NewRows(statuses []monitor.HPAStatus, store *history.Store, since time.Time) []Row {
+	rows := make([]Row, 0, len(statuses))
+	for _, status := range statuses {
+		row := Row{
+			Namespace:       status.Namespace,
+			Name:            status.Name,
+			MinReplicas:     status.MinReplicas,
+			MaxReplicas:     status.MaxReplicas,
+			CurrentReplicas: status.CurrentReplicas,
+			DesiredReplicas: status.DesiredReplicas,
+			Metric:          status.PrimaryMetricName,
+			MetricCurrent:   status.PrimaryMetricCurrent,
+			MetricTarget:    status.PrimaryMetricTarget,
+			Ratio:           status.Ratio,
+			Tolerance:       status.Tolerance,
+			Ready:           status.Ready,
+			DerivedStatus:   status.DerivedStatus,
+			Owner:           status.Owner,
+			LastScaleTime:   status.LastScaleTime,
+		}
+		if store != nil {
+			row.History = history.Summarize(store.Samples(status.Namespace, status.Name, since))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Write encodes rows in the given format. withHistory adds the history columns to
+// CSV and XLSX; NDJSON includes history whenever a row has it.
+func Write(w io.Writer, format string, rows []Row, withHistory bool) error {
+	columns := statusColumns
+	if withHistory {
+		columns = append(append([]column{}, statusColumns...), historyColumns...)
+	}
+
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, columns, rows)
+	case FormatNDJSON:
+		return writeNDJSON(w, rows)
+	case FormatXLSX:
+		return writeXLSX(w, columns, rows)
+	}
+	_, err := ContentType(format)
+	return err
+}
+
+// writeCSV writes a header line and one line per row
+func writeCSV(w io.Writer, columns []column, rows []Row) error {
+	writer := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		record[i] = col.header
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, col := range columns {
+			record[i] = col.value(row)
+			if !col.numeric {
+				record[i] = escapeFormula(record[i])
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formulaPrefixes start cells that spreadsheets evaluate as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes text that a spreadsheet would run as a formula, such as an
+// owner annotation of =HYPERLINK(...), with a quote so it is shown as text
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// writeNDJSON writes one JSON object per line
+func writeNDJSON(w io.Writer, rows []Row) error {
+	encoder := json.NewEncoder(w)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// historyValue formats a history field, or returns an empty cell without history
+func historyValue(value func(*history.Summary) string) func(Row) string {
+	return func(r Row) string {
+		if r.History == nil {
+			return ""
+		}
+		return value(r.History)
+	}
+}
+
+// formatInt formats a replica count
+func formatInt(value int32) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// formatFloat formats a number with up to three decimals and no trailing zeros
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+}
+
+// formatString returns the value of an optional string, or an empty cell
+func formatString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// ParseWindow parses a history window such as 24h, 90m or 7d
+func ParseWindow(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if window, err := time.ParseDuration(raw); err == nil && window > 0 {
+		return window, nil
+	}
+	return 0, fmt.Errorf("invalid window %q: must be a positive duration such as 24h or 7d", raw)
+}
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"hpa-monitor/pkg/history"
+)
+
+// rows returns two HPAs, the second with history and an owner that is a formula
+func rows() []Row {
+	target := "50%"
+	ratio := 1.234
+	return []Row{
+		{Namespace: "shop", Name: "checkout", MinReplicas: 2, MaxReplicas: 10, CurrentReplicas: 4, DesiredReplicas: 5,
+			Metric: "cpu", MetricTarget: &target, Ratio: &ratio, Tolerance: 0.1, Ready: true, DerivedStatus: "Healthy", Owner: "team-checkout"},
+		{Namespace: "batch", Name: "report-worker", MinReplicas: 1, MaxReplicas: 3, CurrentReplicas: 3, DesiredReplicas: 3,
+			Tolerance: 0.1, DerivedStatus: "TooManyReplicas – capped at max", Owner: `=HYPERLINK("https://example.com","owner")`,
+			History: &history.Summary{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Samples: 3, AvgReplicas: 2.5, P95Replicas: 3, PeakReplicas: 3, TimeAtMax: 3600}},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, rows(), true); err != nil {
+		t.Fatalf("writing CSV: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want a header and 2 rows", len(records))
+	}
+
+	wantHeader := "namespace,name,minReplicas,maxReplicas,currentReplicas,desiredReplicas,metric,metricCurrent,metricTarget," +
+		"ratio,tolerance,ready,derivedStatus,owner,lastScaleTime," +
+		"historyFrom,historyTo,samples,avgReplicas,p95Replicas,peakReplicas,timeAtMaxSeconds,timeOutOfToleranceSeconds"
+	if got := strings.Join(records[0], ","); got != wantHeader {
+		t.Errorf("header\n%s\nwant\n%s", got, wantHeader)
+	}
+	if got, want := strings.Join(records[1], ","), "shop,checkout,2,10,4,5,cpu,,50%,1.234,0.1,true,Healthy,team-checkout,,,,,,,,,"; got != want {
+		t.Errorf("first row\n%s\nwant\n%s", got, want)
+	}
+
+	second := make(map[string]string, len(records[0]))
+	for i, header := range records[0] {
+		second[header] = records[2][i]
+	}
+	if got := second["owner"]; got != `'=HYPERLINK("https://example.com","owner")` {
+		t.Errorf("owner %q, want the formula prefixed with a quote", got)
+	}
+	if second["historyFrom"] != "2024-01-01T00:00:00Z" || second["avgReplicas"] != "2.5" || second["timeAtMaxSeconds"] != "3600" {
+		t.Errorf("history columns %v", second)
+	}
+
+	// Without history the history columns are left out
+	buf.Reset()
+	if err := Write(&buf, FormatCSV, rows(), false); err != nil {
+		t.Fatalf("writing CSV: %v", err)
+	}
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	if !strings.HasSuffix(header, ",lastScaleTime") {
+		t.Errorf("header without history %q, want it to end at lastScaleTime", header)
+	}
+}
+
+func TestEscapeFormula(t *testing.T) {
+	tests := map[string]string{
+		"=1+1":          "'=1+1",
+		"+1":            "'+1",
+		"-1":            "'-1",
+		"@SUM(A1)":      "'@SUM(A1)",
+		"\tcmd":         "'\tcmd",
+		"team-checkout": "team-checkout",
+		"":              "",
+	}
+	for value, want := range tests {
+		if got := escapeFormula(value); got != want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatNDJSON, rows(), false); err != nil {
+		t.Fatalf("writing NDJSON: %v", err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	var names []string
+	for scanner.Scan() {
+		var row Row
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", len(names)+1, err)
+		}
+		names = append(names, row.Namespace+"/"+row.Name)
+		// NDJSON keeps values as they are, and history whenever a row has it
+		if row.Name == "report-worker" && (row.History == nil || !strings.HasPrefix(row.Owner, "=")) {
+			t.Errorf("row %+v, want the raw owner and its history", row)
+		}
+	}
+	if got := strings.Join(names, ","); got != "shop/checkout,batch/report-worker" {
+		t.Errorf("rows %s, want one line per HPA in order", got)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatXLSX, rows(), true); err != nil {
+		t.Fatalf("writing XLSX: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("opening XLSX as zip: %v", err)
+	}
+
+	parts := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("opening %s: %v", file.Name, err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("reading %s: %v", file.Name, err)
+		}
+		parts[file.Name] = data
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				T      string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatalf("parsing sheet XML: %v", err)
+	}
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("got %d sheet rows, want a header and 2 rows", len(sheet.Rows))
+	}
+	header := sheet.Rows[0].Cells
+	if len(header) != len(statusColumns)+len(historyColumns) || header[0].Inline != "namespace" || header[0].R != "A1" {
+		t.Errorf("header cells %+v", header)
+	}
+
+	cells := make(map[string]string)
+	types := make(map[string]string)
+	for _, row := range sheet.Rows {
+		for _, cell := range row.Cells {
+			cells[cell.R] = cell.Value + cell.Inline
+			types[cell.R] = cell.T
+		}
+	}
+	// Numbers are number cells and text is inline, escaped and not treated as a formula
+	if cells["C2"] != "2" || types["C2"] != "" {
+		t.Errorf("minReplicas cell %q of type %q, want the number 2", cells["C2"], types["C2"])
+	}
+	if cells["M3"] != "TooManyReplicas – capped at max" || types["M3"] != "inlineStr" {
+		t.Errorf("derivedStatus cell %q of type %q, want inline text", cells["M3"], types["M3"])
+	}
+	if cells["N3"] != `=HYPERLINK("https://example.com","owner")` || types["N3"] != "inlineStr" {
+		t.Errorf("owner cell %q of type %q, want the text as is", cells["N3"], types["N3"])
+	}
+	if _, ok := cells["W3"]; !ok {
+		t.Errorf("no cell W3 for timeOutOfToleranceSeconds in %v", cells)
+	}
+
+	// Columns past Z get two-letter references
+	for col, want := range map[int]string{0: "A1", 25: "Z1", 26: "AA1", 51: "AZ1", 52: "BA1"} {
+		if got := cellRef(col, 1); got != want {
+			t.Errorf("cellRef(%d, 1) = %s, want %s", col, got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// sheetName is the name of the single worksheet in XLSX exports
+const sheetName = "HPAs"
+
+// xlsxParts are the fixed parts of a single-sheet workbook; the sheet itself is
+// generated. Cells use inline strings, so no shared string table is needed.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + sheetName + `" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// writeXLSX writes a workbook with one sheet holding a frozen header row and one row per HPA
+func writeXLSX(w io.Writer, columns []column, rows []Row) error {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(worksheet(columns, rows)); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// worksheet renders the sheet XML
+func worksheet(columns []column, rows []Row) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	// Keep the header visible while scrolling
+	buf.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	buf.WriteString(`<sheetData>`)
+
+	buf.WriteString(`<row r="1">`)
+	for i, col := range columns {
+		writeCell(&buf, cellRef(i, 1), col.header, false)
+	}
+	buf.WriteString(`</row>`)
+
+	for r, row := range rows {
+		line := r + 2
+		buf.WriteString(`<row r="` + strconv.Itoa(line) + `">`)
+		for i, col := range columns {
+			if value := col.value(row); value != "" {
+				writeCell(&buf, cellRef(i, line), value, col.numeric)
+			}
+		}
+		buf.WriteString(`</row>`)
+	}
+
+	buf.WriteString(`</sheetData>`)
+	buf.WriteString(`</worksheet>`)
+	return buf.Bytes()
+}
+
+// writeCell writes a number cell, or an inline string cell
+func writeCell(buf *bytes.Buffer, ref, value string, numeric bool) {
+	if numeric {
+		buf.WriteString(`<c r="` + ref + `"><v>` + value + `</v></c>`)
+		return
+	}
+	buf.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(buf, []byte(value))
+	buf.WriteString(`</t></is></c>`)
+}
+
+// cellRef returns the A1-style reference of a zero-based column and one-based row
+func cellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
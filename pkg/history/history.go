@@ -0,0 +1,280 @@
+package history
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+)
+
+// Sample is a point-in-time observation of a single HPA
+type Sample struct {
+	Timestamp       time.Time `json:"timestamp"`
+	MinReplicas     int32     `json:"minReplicas"`
+	MaxReplicas     int32     `json:"maxReplicas"`
+	CurrentReplicas int32     `json:"currentReplicas"`
+	DesiredReplicas int32     `json:"desiredReplicas"`
+	MetricName      string    `json:"metricName"`
+	MetricCurrent   *float64  `json:"metricCurrent"`
+	MetricTarget    *float64  `json:"metricTarget"`
+	Utilization     bool      `json:"utilization"`
+	Ratio           *float64  `json:"ratio"`
+	// ScaleUpTolerance and ScaleDownTolerance are the effective tolerances when the sample was taken
+	ScaleUpTolerance   float64 `json:"scaleUpTolerance"`
+	ScaleDownTolerance float64 `json:"scaleDownTolerance"`
+}
+
+// ConditionTransition is a change of an HPA condition's status or reason between samples
+type ConditionTransition struct {
+	Timestamp          time.Time `json:"timestamp"`
+	Type               string    `json:"type"`
+	FromStatus         string    `json:"fromStatus"`
+	FromReason         string    `json:"fromReason"`
+	ToStatus           string    `json:"toStatus"`
+	ToReason           string    `json:"toReason"`
+	Message            string    `json:"message"`
+	LastTransitionTime string    `json:"lastTransitionTime"`
+}
+
+// Store keeps a bounded in-memory history of samples per HPA
+type Store struct {
+	mu          sync.RWMutex
+	retention   time.Duration
+	interval    time.Duration
+	maxSamples  int
+	samples     map[string][]Sample
+	conditions  map[string]map[string]monitor.Condition
+	transitions map[string][]ConditionTransition
+}
+
+// NewStore creates a history store that samples every interval and keeps data for retention
+func NewStore(interval, retention time.Duration) *Store {
+	return &Store{
+		retention:   retention,
+		interval:    interval,
+		samples:     make(map[string][]Sample),
+		conditions:  make(map[string]map[string]monitor.Condition),
+		transitions: make(map[string][]ConditionTransition),
+	}
+}
+
+// Key returns the map key for an HPA
+func Key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// SetMaxSamples caps the samples kept across all HPAs; 0 keeps every sample within retention.
+// Each sample takes about 135 bytes, so the default 60s interval and 14 days of retention
+// hold about 20k samples, or 2.7MB, per HPA.
+func (s *Store) SetMaxSamples(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxSamples = n
+}
+
+// Retention returns how long samples are kept
+func (s *Store) Retention() time.Duration {
+	return s.retention
+}
+
+// Record stores one sample per HPA status and prunes samples past retention
+func (s *Store) Record(statuses []monitor.HPAStatus, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := at.Add(-s.retention)
+	for _, status := range statuses {
+		key := Key(status.Namespace, status.Name)
+		samples := append(prune(s.samples[key], cutoff, at), newSample(status, at))
+		s.samples[key] = samples
+		s.recordConditions(key, status.Conditions, cutoff, at)
+	}
+
+	// Drop HPAs that have not been seen within the retention window
+	for key, samples := range s.samples {
+		if len(samples) == 0 || samples[len(samples)-1].Timestamp.Before(cutoff) {
+			delete(s.samples, key)
+			delete(s.conditions, key)
+			delete(s.transitions, key)
+		}
+	}
+	s.trim()
+}
+
+// trim drops the oldest samples once the total exceeds maxSamples. The longest histories are
+// shortened to an equal share, and HPAs with fewer samples than that keep all of theirs.
+func (s *Store) trim() {
+	total := 0
+	lengths := make([]int, 0, len(s.samples))
+	for _, samples := range s.samples {
+		total += len(samples)
+		lengths = append(lengths, len(samples))
+	}
+	if s.maxSamples <= 0 || total <= s.maxSamples {
+		return
+	}
+
+	sort.Ints(lengths)
+	limit, remaining := 0, s.maxSamples
+	for i, length := range lengths {
+		share := remaining / (len(lengths) - i)
+		if length > share {
+			limit = share
+			break
+		}
+		remaining -= length
+	}
+	for key, samples := range s.samples {
+		if len(samples) > limit {
+			s.samples[key] = slices.Clone(samples[len(samples)-limit:])
+		}
+	}
+}
+
+// recordConditions appends a transition for each condition whose status or reason changed
+// since the previous sample. The first observation of an HPA only sets the baseline.
+func (s *Store) recordConditions(key string, conditions []monitor.Condition, cutoff, at time.Time) {
+	previous, seen := s.conditions[key]
+	current := make(map[string]monitor.Condition, len(conditions))
+
+	transitions := s.transitions[key]
+	start := 0
+	for start < len(transitions) && transitions[start].Timestamp.Before(cutoff) {
+		start++
+	}
+	transitions = transitions[start:]
+
+	for _, condition := range conditions {
+		current[condition.Type] = condition
+		if !seen {
+			continue
+		}
+		before := previous[condition.Type]
+		if before.Status == condition.Status && before.Reason == condition.Reason {
+			continue
+		}
+		transitions = append(transitions, ConditionTransition{
+			Timestamp:          at,
+			Type:               condition.Type,
+			FromStatus:         before.Status,
+			FromReason:         before.Reason,
+			ToStatus:           condition.Status,
+			ToReason:           condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime,
+		})
+	}
+
+	s.conditions[key] = current
+	s.transitions[key] = transitions
+}
+
+// Samples returns the samples recorded for an HPA since the given time, oldest first
+func (s *Store) Samples(namespace, name string, since time.Time) []Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	samples := s.samples[Key(namespace, name)]
+	idx := sort.Search(len(samples), func(i int) bool {
+		return !samples[i].Timestamp.Before(since)
+	})
+
+	result := make([]Sample, len(samples)-idx)
+	copy(result, samples[idx:])
+	return result
+}
+
+// Transitions returns the condition transitions recorded for an HPA since the given time, oldest first
+func (s *Store) Transitions(namespace, name string, since time.Time) []ConditionTransition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []ConditionTransition{}
+	for _, transition := range s.transitions[Key(namespace, name)] {
+		if !transition.Timestamp.Before(since) {
+			result = append(result, transition)
+		}
+	}
+	return result
+}
+
+// Run records HPA statuses every interval until the context is cancelled
+func (s *Store) Run(ctx context.Context, hpaMonitor *monitor.HPAMonitor) {
+	log := logger.GetLogger()
+	log.WithFields(logger.Fields{
+		"interval":  s.interval.String(),
+		"retention": s.retention.String(),
+	}).Info("History recorder started")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.collect(ctx, hpaMonitor)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("History recorder stopped")
+			return
+		case <-ticker.C:
+			s.collect(ctx, hpaMonitor)
+		}
+	}
+}
+
+// collect fetches the current HPA statuses and records them
+func (s *Store) collect(ctx context.Context, hpaMonitor *monitor.HPAMonitor) {
+	log := logger.GetLogger()
+
+	statuses, err := hpaMonitor.GetHPAStatus(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to collect HPA status for history")
+		return
+	}
+
+	s.Record(statuses, hpaMonitor.Now())
+	log.WithField("hpa_count", len(statuses)).Debug("History sample recorded")
+}
+
+// newSample converts an HPA status into a history sample
+func newSample(status monitor.HPAStatus, at time.Time) Sample {
+	sample := Sample{
+		Timestamp:       at,
+		MinReplicas:     status.MinReplicas,
+		MaxReplicas:     status.MaxReplicas,
+		CurrentReplicas: status.CurrentReplicas,
+		DesiredReplicas: status.DesiredReplicas,
+		MetricName:      status.PrimaryMetricName,
+		Ratio:           status.Ratio,
+
+		ScaleUpTolerance:   status.ScaleUpTolerance,
+		ScaleDownTolerance: status.ScaleDownTolerance,
+	}
+
+	if status.PrimaryMetricCurrent != nil {
+		sample.MetricCurrent = monitor.ParseMetricValue(*status.PrimaryMetricCurrent)
+	}
+	if status.PrimaryMetricTarget != nil {
+		sample.MetricTarget = monitor.ParseMetricValue(*status.PrimaryMetricTarget)
+		sample.Utilization = strings.HasSuffix(*status.PrimaryMetricTarget, "%")
+	}
+
+	return sample
+}
+
+// prune drops samples older than cutoff, and samples after at when the clock moved backwards
+func prune(samples []Sample, cutoff, at time.Time) []Sample {
+	end := len(samples)
+	for end > 0 && samples[end-1].Timestamp.After(at) {
+		end--
+	}
+	start := 0
+	for start < end && samples[start].Timestamp.Before(cutoff) {
+		start++
+	}
+	return samples[start:end]
+}
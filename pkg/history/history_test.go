@@ -0,0 +1,64 @@
+package history
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"hpa-monitor/pkg/monitor"
+)
+
+func TestRecordCapsTotalSamples(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(time.Minute, 24*time.Hour)
+	store.SetMaxSamples(10)
+
+	// checkout is sampled for 12 minutes, and storefront joins for the last 3
+	checkout := monitor.HPAStatus{Namespace: "shop", Name: "checkout"}
+	storefront := monitor.HPAStatus{Namespace: "shop", Name: "storefront"}
+	for i := range 12 {
+		statuses := []monitor.HPAStatus{checkout}
+		if i >= 9 {
+			statuses = append(statuses, storefront)
+		}
+		store.Record(statuses, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	// The longer history gives up its oldest samples and the shorter one keeps all of its own
+	samples := store.Samples("shop", "checkout", time.Time{})
+	if len(samples) != 7 || !samples[0].Timestamp.Equal(start.Add(5*time.Minute)) {
+		t.Errorf("checkout kept %d samples from %v, want the 7 from 5m", len(samples), samples[0].Timestamp.Sub(start))
+	}
+	if got := len(store.Samples("shop", "storefront", time.Time{})); got != 3 {
+		t.Errorf("storefront kept %d samples, want 3", got)
+	}
+
+	// Once both are over an equal share, each keeps half
+	for i := 12; i < 20; i++ {
+		store.Record([]monitor.HPAStatus{checkout, storefront}, start.Add(time.Duration(i)*time.Minute))
+	}
+	for _, name := range []string{"checkout", "storefront"} {
+		if got := len(store.Samples("shop", name, time.Time{})); got != 5 {
+			t.Errorf("%s kept %d samples, want 5", name, got)
+		}
+	}
+}
+
+func TestRecordWithoutCap(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(time.Minute, time.Hour)
+	var statuses []monitor.HPAStatus
+	for i := range 3 {
+		statuses = append(statuses, monitor.HPAStatus{Namespace: "shop", Name: "hpa-" + strconv.Itoa(i)})
+	}
+	for i := range 90 {
+		store.Record(statuses, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	// Only retention applies: the last hour of samples, 61 including both ends
+	for _, status := range statuses {
+		if got := len(store.Samples(status.Namespace, status.Name, time.Time{})); got != 61 {
+			t.Errorf("%s kept %d samples, want 61", status.Name, got)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package history
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Summary aggregates the replica history of one HPA over a window
+type Summary struct {
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Samples      int       `json:"samples"`
+	AvgReplicas  float64   `json:"avgReplicas"`
+	P95Replicas  float64   `json:"p95Replicas"`
+	PeakReplicas int32     `json:"peakReplicas"`
+	// TimeAtMax and TimeOutOfTolerance are in seconds; each sample counts until the next one
+	TimeAtMax          float64 `json:"timeAtMaxSeconds"`
+	TimeOutOfTolerance float64 `json:"timeOutOfToleranceSeconds"`
+}
+
+// Summarize aggregates samples, oldest first. It returns nil without samples.
+func Summarize(samples []Sample) *Summary {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	summary := &Summary{
+		From:    samples[0].Timestamp,
+		To:      samples[len(samples)-1].Timestamp,
+		Samples: len(samples),
+	}
+	replicas := make([]float64, len(samples))
+	sum := 0.0
+	for i, sample := range samples {
+		replicas[i] = float64(sample.CurrentReplicas)
+		sum += replicas[i]
+		if sample.CurrentReplicas > summary.PeakReplicas {
+			summary.PeakReplicas = sample.CurrentReplicas
+		}
+
+		if i == len(samples)-1 {
+			continue
+		}
+		elapsed := samples[i+1].Timestamp.Sub(sample.Timestamp).Seconds()
+		// Samples without a known maximum are never at max
+		if sample.MaxReplicas > 0 && sample.CurrentReplicas >= sample.MaxReplicas {
+			summary.TimeAtMax += elapsed
+		}
+		if sample.OutOfTolerance() {
+			summary.TimeOutOfTolerance += elapsed
+		}
+	}
+	summary.AvgReplicas = sum / float64(len(samples))
+	summary.P95Replicas = Percentile(replicas, 95)
+	return summary
+}
+
+// OutOfTolerance reports whether the ratio differs from 1.0 by more than the tolerance of
+// the direction it points in, which is when the HPA controller scales
+func (s Sample) OutOfTolerance() bool {
+	if s.Ratio == nil {
+		return false
+	}
+	tolerance := s.ScaleUpTolerance
+	if *s.Ratio < 1.0 {
+		tolerance = s.ScaleDownTolerance
+	}
+	return math.Abs(*s.Ratio-1.0) > tolerance
+}
+
+// Percentile returns the p-th percentile (0-100) using nearest-rank
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
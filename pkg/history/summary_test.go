@@ -0,0 +1,37 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ratio := func(value float64) *float64 { return &value }
+	samples := []Sample{
+		// Out of tolerance under the 0.1 tolerance in effect then, not under a later 0.2
+		{CurrentReplicas: 4, MaxReplicas: 10, Ratio: ratio(1.15), ScaleUpTolerance: 0.1, ScaleDownTolerance: 0.1},
+		// Within the wider scale down tolerance, although beyond the scale up one
+		{CurrentReplicas: 10, MaxReplicas: 10, Ratio: ratio(0.8), ScaleUpTolerance: 0.1, ScaleDownTolerance: 0.3},
+		// Exactly at the tolerance does not scale
+		{CurrentReplicas: 3, MaxReplicas: 0, Ratio: ratio(1.2), ScaleUpTolerance: 0.2, ScaleDownTolerance: 0.2},
+		{CurrentReplicas: 3, MaxReplicas: 10, Ratio: ratio(1.0), ScaleUpTolerance: 0.2, ScaleDownTolerance: 0.2},
+	}
+	for i := range samples {
+		samples[i].Timestamp = start.Add(time.Duration(i) * time.Minute)
+	}
+
+	summary := Summarize(samples)
+	if summary.TimeOutOfTolerance != 60 {
+		t.Errorf("TimeOutOfTolerance %v, want 60", summary.TimeOutOfTolerance)
+	}
+	if summary.TimeAtMax != 60 {
+		t.Errorf("TimeAtMax %v, want 60", summary.TimeAtMax)
+	}
+	if summary.PeakReplicas != 10 || summary.Samples != 4 {
+		t.Errorf("PeakReplicas %d and Samples %d, want 10 and 4", summary.PeakReplicas, summary.Samples)
+	}
+	if Summarize(nil) != nil {
+		t.Error("Summarize(nil) is not nil")
+	}
+}
@@ -0,0 +1,142 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"hpa-monitor/pkg/logger"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Status describes this replica's view of leadership
+type Status struct {
+	Enabled  bool   `json:"enabled"`
+	Identity string `json:"identity"`
+	Leader   string `json:"leader"`
+	IsLeader bool   `json:"isLeader"`
+}
+
+// Elector runs leader-only work on the replica holding the Lease
+type Elector struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+	identity  string
+	enabled   bool
+
+	mu       sync.RWMutex
+	leader   string
+	isLeader bool
+}
+
+// NewElector creates an elector using a Lease in namespace with the given name
+func NewElector(client kubernetes.Interface, namespace, name, identity string) *Elector {
+	return &Elector{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		identity:  identity,
+		enabled:   true,
+	}
+}
+
+// NewStandalone creates an elector for a single replica that always leads, from creation on
+func NewStandalone(identity string) *Elector {
+	return &Elector{identity: identity, leader: identity, isLeader: true}
+}
+
+// Status returns the current leadership state
+func (e *Elector) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return Status{
+		Enabled:  e.enabled,
+		Identity: e.identity,
+		Leader:   e.leader,
+		IsLeader: e.isLeader,
+	}
+}
+
+// IsLeader reports whether this replica currently leads
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader
+}
+
+// Run calls leaderTasks whenever this replica acquires leadership; the context passed to
+// leaderTasks is cancelled when leadership is lost. Run blocks until ctx is cancelled.
+// A standalone elector starts the tasks immediately.
+func (e *Elector) Run(ctx context.Context, leaderTasks func(ctx context.Context)) {
+	log := logger.GetLogger()
+
+	if !e.enabled {
+		e.setLeader(e.identity, true)
+		leaderTasks(ctx)
+		<-ctx.Done()
+		return
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      e.name,
+			Namespace: e.namespace,
+		},
+		Client: e.client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: e.identity,
+		},
+	}
+
+	log.WithFields(logger.Fields{
+		"namespace": e.namespace,
+		"lease":     e.name,
+		"identity":  e.identity,
+	}).Info("Starting leader election")
+
+	// A replica that loses leadership rejoins the election until shutdown
+	for ctx.Err() == nil {
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            e.name,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(leaderCtx context.Context) {
+					log.WithField("identity", e.identity).Info("Acquired leadership")
+					e.setLeader(e.identity, true)
+					leaderTasks(leaderCtx)
+				},
+				OnStoppedLeading: func() {
+					log.WithField("identity", e.identity).Info("Lost leadership")
+					e.setLeader("", false)
+				},
+				OnNewLeader: func(identity string) {
+					log.WithField("leader", identity).Info("Observed new leader")
+					e.setLeader(identity, identity == e.identity)
+				},
+			},
+		})
+	}
+}
+
+// setLeader records the current leader identity
+func (e *Elector) setLeader(identity string, isLeader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = identity
+	e.isLeader = isLeader
+}
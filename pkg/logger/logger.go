@@ -35,6 +35,16 @@ func InitLogger(level string) {
 	}).Info("Logger initialized")
 }
 
+// SetLevel changes the log level of the global logger
+func SetLevel(level string) {
+	logLevel := parseLogLevel(level)
+	GetLogger().SetLevel(logLevel)
+	
+	Logger.WithFields(logrus.Fields{
+		"log_level": logLevel.String(),
+	}).Info("Log level updated")
+}
+
 // parseLogLevel parses string log level to logrus.Level
 func parseLogLevel(level string) logrus.Level {
 	switch strings.ToLower(level) {
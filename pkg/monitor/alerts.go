@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"strings"
+	"time"
+)
+
+// Alert is an HPA whose derived status is neither healthy nor unknown
+type Alert struct {
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	DerivedStatus string `json:"derivedStatus"`
+	ConditionType string `json:"conditionType"`
+	Reason        string `json:"reason"`
+	Message       string `json:"message"`
+	// Since is when the condition behind the alert last changed, nil when the controller did not report it
+	Since       *time.Time `json:"since"`
+	MaxDuration string     `json:"maxDuration,omitempty"`
+	Firing      bool       `json:"firing"`
+	Owner       string     `json:"owner,omitempty"`
+	Runbook     string     `json:"runbook,omitempty"`
+}
+
+// Alerts returns an alert for every unhealthy HPA. An alert fires once it has lasted longer than
+// the HPA's alert-max-duration annotation, and right away when the annotation is not set.
+func Alerts(statuses []HPAStatus, now time.Time) []Alert {
+	var alerts []Alert
+	for _, status := range statuses {
+		if status.DerivedStatus == DerivedStatusHealthy || status.DerivedStatus == DerivedStatusUnknown {
+			continue
+		}
+
+		alert := Alert{
+			Namespace:     status.Namespace,
+			Name:          status.Name,
+			DerivedStatus: status.DerivedStatus,
+			MaxDuration:   status.AlertMaxDuration,
+			Firing:        true,
+			Owner:         status.Owner,
+			Runbook:       status.Runbook,
+		}
+
+		// The derived status starts with the reason of the condition it was derived from
+		reason, _, _ := strings.Cut(status.DerivedStatus, " – ")
+		for _, condition := range status.Conditions {
+			if condition.Reason != reason {
+				continue
+			}
+			alert.ConditionType = condition.Type
+			alert.Reason = condition.Reason
+			alert.Message = condition.Message
+			if since, err := time.Parse(time.RFC3339, condition.LastTransitionTime); err == nil {
+				alert.Since = &since
+			}
+			break
+		}
+
+		if maxDuration, err := time.ParseDuration(status.AlertMaxDuration); err == nil && alert.Since != nil {
+			alert.Firing = now.Sub(*alert.Since) > maxDuration
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
@@ -0,0 +1,195 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"hpa-monitor/pkg/logger"
+)
+
+// Annotations read from HPAs, and from namespaces as defaults for their HPAs
+const (
+	AnnotationTolerance        = "hpa-monitor.io/tolerance"
+	AnnotationIgnore           = "hpa-monitor.io/ignore"
+	AnnotationOwner            = "hpa-monitor.io/owner"
+	AnnotationRunbook          = "hpa-monitor.io/runbook"
+	AnnotationAlertMaxDuration = "hpa-monitor.io/alert-max-duration"
+)
+
+// Sources of an HPA's effective tolerance
+const (
+	ToleranceSourceGlobal              = "global"
+	ToleranceSourceNamespaceAnnotation = "namespaceAnnotation"
+	ToleranceSourceAnnotation          = "annotation"
+)
+
+// Settings are the per-HPA settings resolved from annotations
+type Settings struct {
+	Tolerance        *float64
+	ToleranceSource  string
+	Ignore           bool
+	Owner            string
+	Runbook          string
+	AlertMaxDuration string
+	Errors           []string
+}
+
+// resolveSettings applies namespace annotations as defaults and HPA annotations on top.
+// Invalid values are skipped and reported in Errors.
+func resolveSettings(namespaceAnnotations, hpaAnnotations map[string]string) Settings {
+	settings := Settings{ToleranceSource: ToleranceSourceGlobal}
+	applyAnnotations(&settings, namespaceAnnotations, ToleranceSourceNamespaceAnnotation, "namespace")
+	applyAnnotations(&settings, hpaAnnotations, ToleranceSourceAnnotation, "hpa")
+	return settings
+}
+
+// applyAnnotations overrides settings with the valid annotations present in annotations
+func applyAnnotations(settings *Settings, annotations map[string]string, toleranceSource, origin string) {
+	if value, ok := annotations[AnnotationTolerance]; ok {
+		tolerance, err := strconv.ParseFloat(value, 64)
+		if err != nil || tolerance < 0 || tolerance > 1 {
+			settings.Errors = append(settings.Errors, fmt.Sprintf("%s %s: must be a number between 0.0 and 1.0, got %q", origin, AnnotationTolerance, value))
+		} else {
+			settings.Tolerance = &tolerance
+			settings.ToleranceSource = toleranceSource
+		}
+	}
+	if value, ok := annotations[AnnotationIgnore]; ok {
+		ignore, err := strconv.ParseBool(value)
+		if err != nil {
+			settings.Errors = append(settings.Errors, fmt.Sprintf("%s %s: must be true or false, got %q", origin, AnnotationIgnore, value))
+		} else {
+			settings.Ignore = ignore
+		}
+	}
+	if value, ok := annotations[AnnotationOwner]; ok && value != "" {
+		settings.Owner = value
+	}
+	if value, ok := annotations[AnnotationRunbook]; ok && value != "" {
+		settings.Runbook = value
+	}
+	if value, ok := annotations[AnnotationAlertMaxDuration]; ok {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			settings.Errors = append(settings.Errors, fmt.Sprintf("%s %s: must be a positive duration such as 30m, got %q", origin, AnnotationAlertMaxDuration, value))
+		} else {
+			settings.AlertMaxDuration = duration.String()
+		}
+	}
+}
+
+// EffectiveTolerance returns the tolerance the monitor applies to hpa: its annotation, its
+// namespace's annotation, or the global tolerance
+func (hm *HPAMonitor) EffectiveTolerance(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) float64 {
+	var namespaceAnnotations map[string]string
+	if hpa.Namespace != "" {
+		namespaceAnnotations = hm.namespaceAnnotations(ctx, []string{hpa.Namespace})[hpa.Namespace]
+	}
+	if settings := resolveSettings(namespaceAnnotations, hpa.Annotations); settings.Tolerance != nil {
+		return *settings.Tolerance
+	}
+	return hm.GetTolerance()
+}
+
+// ManifestTolerance returns the tolerance the monitor would apply to an HPA manifest that
+// may not exist in the cluster: its annotation or the global tolerance. Namespace
+// annotations are not looked up.
+func (hm *HPAMonitor) ManifestTolerance(hpa *autoscalingv2.HorizontalPodAutoscaler) float64 {
+	if settings := resolveSettings(nil, hpa.Annotations); settings.Tolerance != nil {
+		return *settings.Tolerance
+	}
+	return hm.GetTolerance()
+}
+
+// namespaceAnnotationsTTL is how long namespace annotations are reused before they are
+// read again, so every poll does not list or get namespaces
+const namespaceAnnotationsTTL = 30 * time.Second
+
+// namespaceCache holds the namespace annotations last read from the API server
+type namespaceCache struct {
+	mu sync.Mutex
+	// entries is keyed by namespace; failed reads are not cached
+	entries map[string]*namespaceEntry
+	// listedAt is when every namespace was last listed
+	listedAt time.Time
+}
+
+// namespaceEntry is one namespace's annotations and when they were read
+type namespaceEntry struct {
+	annotations map[string]string
+	readAt      time.Time
+}
+
+// namespaceAnnotations returns the annotations of the given namespaces, or of all namespaces
+// when unscoped, reading them at most every namespaceAnnotationsTTL. Missing permissions are
+// logged and treated as no defaults.
+func (hm *HPAMonitor) namespaceAnnotations(ctx context.Context, namespaces []string) map[string]map[string]string {
+	log := logger.GetLogger()
+	cache := &hm.namespaceCache
+	result := make(map[string]map[string]string)
+	now := time.Now()
+
+	if len(namespaces) == 0 {
+		cache.mu.Lock()
+		fresh := now.Sub(cache.listedAt) < namespaceAnnotationsTTL
+		if fresh {
+			for name, entry := range cache.entries {
+				result[name] = entry.annotations
+			}
+		}
+		cache.mu.Unlock()
+		if fresh {
+			return result
+		}
+
+		callCtx, cancel := hm.callContext(ctx)
+		defer cancel()
+		namespaceList, err := hm.client.CoreV1().Namespaces().List(callCtx, metav1.ListOptions{})
+		if err != nil {
+			log.WithError(err).Warn("Failed to list namespaces, namespace annotation defaults are not applied")
+			return result
+		}
+		entries := make(map[string]*namespaceEntry, len(namespaceList.Items))
+		for _, namespace := range namespaceList.Items {
+			result[namespace.Name] = namespace.Annotations
+			entries[namespace.Name] = &namespaceEntry{annotations: namespace.Annotations, readAt: now}
+		}
+		cache.mu.Lock()
+		cache.entries = entries
+		cache.listedAt = now
+		cache.mu.Unlock()
+		return result
+	}
+
+	for _, name := range namespaces {
+		cache.mu.Lock()
+		entry, ok := cache.entries[name]
+		cache.mu.Unlock()
+		if ok && now.Sub(entry.readAt) < namespaceAnnotationsTTL {
+			result[name] = entry.annotations
+			continue
+		}
+
+		callCtx, cancel := hm.callContext(ctx)
+		namespace, err := hm.client.CoreV1().Namespaces().Get(callCtx, name, metav1.GetOptions{})
+		cancel()
+		if err != nil {
+			log.WithField("namespace", name).WithError(err).Warn("Failed to get namespace, namespace annotation defaults are not applied")
+			continue
+		}
+		result[name] = namespace.Annotations
+		cache.mu.Lock()
+		if cache.entries == nil {
+			cache.entries = make(map[string]*namespaceEntry)
+		}
+		cache.entries[name] = &namespaceEntry{annotations: namespace.Annotations, readAt: now}
+		cache.mu.Unlock()
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceAnnotationsAreCached(t *testing.T) {
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "shop",
+		Annotations: map[string]string{AnnotationOwner: "team-shop"},
+	}}
+	clientset := fake.NewSimpleClientset(
+		namespace,
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+	)
+	hm := NewHPAMonitor(clientset)
+	ctx := t.Context()
+
+	// namespaceCalls returns the namespace API calls since the last one
+	namespaceCalls := func() string {
+		var calls []string
+		for _, action := range clientset.Actions() {
+			if action.GetResource().Resource == "namespaces" {
+				calls = append(calls, action.GetVerb())
+			}
+		}
+		clientset.ClearActions()
+		return strings.Join(calls, ", ")
+	}
+	owner := func(opts StatusOptions) string {
+		t.Helper()
+		statuses, err := hm.ListHPAStatus(ctx, opts)
+		if err != nil || len(statuses) != 1 {
+			t.Fatalf("listing HPAs: %v, %v", statuses, err)
+		}
+		return statuses[0].Owner
+	}
+
+	if got := owner(StatusOptions{SkipEvents: true}); got != "team-shop" {
+		t.Errorf("owner %q, want team-shop from the namespace", got)
+	}
+	if got := namespaceCalls(); got != "list" {
+		t.Errorf("first poll: namespace calls %q, want list", got)
+	}
+
+	// Within the TTL neither an unscoped nor a scoped poll reads namespaces again
+	namespace.Annotations[AnnotationOwner] = "team-payments"
+	if _, err := clientset.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("updating namespace: %v", err)
+	}
+	clientset.ClearActions()
+	owner(StatusOptions{SkipEvents: true})
+	if got := owner(StatusOptions{Namespaces: []string{"shop"}, SkipEvents: true}); got != "team-shop" {
+		t.Errorf("owner %q within the TTL, want the cached team-shop", got)
+	}
+	if got := namespaceCalls(); got != "" {
+		t.Errorf("polls within the TTL: namespace calls %q, want none", got)
+	}
+
+	// Once expired, the changed annotation applies
+	hm.namespaceCache.listedAt = hm.namespaceCache.listedAt.Add(-namespaceAnnotationsTTL)
+	hm.namespaceCache.entries["shop"].readAt = hm.namespaceCache.entries["shop"].readAt.Add(-namespaceAnnotationsTTL)
+	if got := owner(StatusOptions{Namespaces: []string{"shop"}, SkipEvents: true}); got != "team-payments" {
+		t.Errorf("owner %q after the TTL, want team-payments", got)
+	}
+	if got := owner(StatusOptions{SkipEvents: true}); got != "team-payments" {
+		t.Errorf("owner %q after the TTL, want team-payments", got)
+	}
+	if got := namespaceCalls(); got != "get, list" {
+		t.Errorf("polls after the TTL: namespace calls %q, want get, list", got)
+	}
+}
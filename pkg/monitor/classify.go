@@ -0,0 +1,233 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Event categories assigned by the classifier
+const (
+	CategoryMetricsServerUnavailable = "metrics-server-unavailable"
+	CategoryMissingRequests          = "missing-requests"
+	CategoryAdapterError             = "adapter-error"
+	CategoryRescaleUp                = "rescale-up"
+	CategoryRescaleDown              = "rescale-down"
+	CategoryScaleFailure             = "scale-failure"
+	CategorySelectorError            = "selector-error"
+	CategoryOther                    = "other"
+)
+
+// categoryHints are remediation hints per category
+var categoryHints = map[string]string{
+	CategoryMetricsServerUnavailable: "Check that metrics-server is running and its APIService v1beta1.metrics.k8s.io is Available.",
+	CategoryMissingRequests:          "Set resource requests for the metric's resource on every container of the target, or use a ContainerResource metric.",
+	CategoryAdapterError:             "Check the custom or external metrics adapter (e.g. prometheus-adapter, KEDA) logs and that the metric name and selector match a series.",
+	CategoryScaleFailure:             "Check that the scale target exists and the HPA controller may get and update its scale subresource.",
+	CategorySelectorError:            "Make sure the target's selector is valid and not shared with another HPA's target.",
+}
+
+var (
+	rescaleSizePattern   = regexp.MustCompile(`New size: (\d+)`)
+	rescaleReasonPattern = regexp.MustCompile(`reason: (.+)$`)
+	// triggerMetricPattern extracts the metric from reasons like "cpu resource utilization (percentage of request) above target"
+	triggerMetricPattern = regexp.MustCompile(`^(.+?) above target$`)
+)
+
+// RunbookData is available to runbook link templates
+type RunbookData struct {
+	Namespace string
+	Name      string
+	Category  string
+	Reason    string
+}
+
+// ParseRunbookTemplate parses a runbook link template such as
+// "https://runbooks.example.com/hpa/{{.Category}}"
+func ParseRunbookTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("runbook").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid runbook template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// classifyEvent sets the category, hint and rescale details of an event from its reason and message
+func classifyEvent(event *Event) {
+	message := strings.ToLower(event.Message)
+
+	switch event.Reason {
+	case "SuccessfulRescale":
+		event.Category = CategoryOther
+		if match := rescaleSizePattern.FindStringSubmatch(event.Message); match != nil {
+			if size, err := strconv.ParseInt(match[1], 10, 32); err == nil {
+				newReplicas := int32(size)
+				event.NewReplicas = &newReplicas
+			}
+		}
+		if match := rescaleReasonPattern.FindStringSubmatch(event.Message); match != nil {
+			reason := match[1]
+			event.Category = rescaleCategory(reason)
+			if trigger := triggerMetricPattern.FindStringSubmatch(reason); trigger != nil {
+				event.TriggerMetric = trigger[1]
+			}
+		}
+	case "FailedGetResourceMetric", "FailedGetContainerResourceMetric", "FailedComputeMetricsReplicas":
+		// Adapter errors also mention "unable to fetch metrics" and their metrics.k8s.io API group,
+		// so they are told apart from metrics-server errors first
+		switch {
+		case strings.Contains(message, "missing request"):
+			event.Category = CategoryMissingRequests
+		case strings.Contains(message, "custom metric"), strings.Contains(message, "external metric"),
+			strings.Contains(message, "custom.metrics.k8s.io"), strings.Contains(message, "external.metrics.k8s.io"):
+			event.Category = CategoryAdapterError
+		case strings.Contains(message, "resource metrics api"),
+			strings.Contains(message, "metrics.k8s.io"),
+			strings.Contains(message, "unable to fetch metrics"):
+			event.Category = CategoryMetricsServerUnavailable
+		default:
+			event.Category = CategoryOther
+		}
+	case "FailedGetPodsMetric", "FailedGetObjectMetric", "FailedGetExternalMetric":
+		event.Category = CategoryAdapterError
+	case "FailedRescale", "FailedGetScale", "FailedUpdateScale", "FailedUpdateStatus":
+		event.Category = CategoryScaleFailure
+	case "InvalidSelector", "AmbiguousSelector", "SelectorRequired":
+		event.Category = CategorySelectorError
+	default:
+		event.Category = CategoryOther
+	}
+
+	event.Hint = categoryHints[event.Category]
+}
+
+// rescaleCategory returns the direction of a SuccessfulRescale reason, or CategoryOther when
+// the reason does not tell
+func rescaleCategory(reason string) string {
+	switch {
+	case strings.HasSuffix(reason, "below target"), strings.Contains(reason, "above Spec.MaxReplicas"):
+		return CategoryRescaleDown
+	case strings.HasSuffix(reason, "above target"), strings.Contains(reason, "below Spec.MinReplicas"),
+		strings.Contains(reason, "must be greater than 0"):
+		return CategoryRescaleUp
+	}
+	return CategoryOther
+}
+
+// rescaleDirection returns CategoryRescaleUp or CategoryRescaleDown comparing replica counts,
+// or "" when they are equal
+func rescaleDirection(from, to int32) string {
+	switch {
+	case to > from:
+		return CategoryRescaleUp
+	case to < from:
+		return CategoryRescaleDown
+	}
+	return ""
+}
+
+// fillRescaleOrigins sets OldReplicas on rescale events from the preceding rescale, since the
+// controller only reports the new size, and sets their direction from the two sizes. The order
+// is ambiguous when an event was aggregated (count > 1) or two rescales share a timestamp,
+// in which case OldReplicas is left unset.
+func fillRescaleOrigins(events []Event) {
+	var rescales []int
+	for i, event := range events {
+		if event.NewReplicas == nil {
+			continue
+		}
+		if event.Count > 1 || event.LastTimestamp == "Unknown" {
+			return
+		}
+		rescales = append(rescales, i)
+	}
+	// RFC3339 timestamps sort chronologically as strings
+	sort.SliceStable(rescales, func(a, b int) bool {
+		return events[rescales[a]].LastTimestamp < events[rescales[b]].LastTimestamp
+	})
+	for k := 1; k < len(rescales); k++ {
+		if events[rescales[k-1]].LastTimestamp == events[rescales[k]].LastTimestamp {
+			return
+		}
+	}
+
+	for k := 1; k < len(rescales); k++ {
+		event := &events[rescales[k]]
+		event.OldReplicas = events[rescales[k-1]].NewReplicas
+		if direction := rescaleDirection(*event.OldReplicas, *event.NewReplicas); direction != "" {
+			event.Category = direction
+		}
+	}
+}
+
+// runbookURL renders the runbook template for problem events, falling back to the HPA's runbook annotation
+func (hm *HPAMonitor) runbookURL(status *HPAStatus, event *Event) string {
+	hm.mu.RLock()
+	tmpl := hm.runbookTemplate
+	hm.mu.RUnlock()
+
+	if tmpl == nil || categoryHints[event.Category] == "" {
+		return status.Runbook
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, RunbookData{
+		Namespace: status.Namespace,
+		Name:      status.Name,
+		Category:  event.Category,
+		Reason:    event.Reason,
+	})
+	if err != nil {
+		return status.Runbook
+	}
+	return buf.String()
+}
+
+// CategoryCount aggregates events of one category across HPAs
+type CategoryCount struct {
+	Category    string `json:"category"`
+	Events      int    `json:"events"`
+	Occurrences int32  `json:"occurrences"`
+	HPAs        int    `json:"hpas"`
+	Hint        string `json:"hint,omitempty"`
+}
+
+// SummarizeEvents counts events per category across all HPA statuses, most frequent first
+func SummarizeEvents(statuses []HPAStatus) []CategoryCount {
+	counts := make(map[string]*CategoryCount)
+	for _, status := range statuses {
+		seen := make(map[string]bool)
+		for _, event := range status.Events {
+			count, ok := counts[event.Category]
+			if !ok {
+				count = &CategoryCount{Category: event.Category, Hint: categoryHints[event.Category]}
+				counts[event.Category] = count
+			}
+			count.Events++
+			count.Occurrences += event.Count
+			if !seen[event.Category] {
+				seen[event.Category] = true
+				count.HPAs++
+			}
+		}
+	}
+
+	result := make([]CategoryCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Occurrences != result[j].Occurrences {
+			return result[i].Occurrences > result[j].Occurrences
+		}
+		return result[i].Category < result[j].Category
+	})
+	return result
+}
@@ -0,0 +1,107 @@
+package monitor
+
+import "testing"
+
+func TestClassifyMetricEvents(t *testing.T) {
+	tests := []struct {
+		reason  string
+		message string
+		want    string
+	}{
+		{
+			"FailedGetResourceMetric",
+			"failed to get cpu utilization: unable to get metrics for resource cpu: unable to fetch metrics from resource metrics API: the server could not find the requested resource (get pods.metrics.k8s.io)",
+			CategoryMetricsServerUnavailable,
+		},
+		{
+			"FailedGetResourceMetric",
+			"failed to get cpu utilization: missing request for cpu in container app of Pod web-1",
+			CategoryMissingRequests,
+		},
+		{
+			"FailedComputeMetricsReplicas",
+			"invalid metrics (1 invalid out of 1), first error is: failed to get pods metric value: unable to get metric http_requests: unable to fetch metrics from custom metrics API: the server could not find the metric http_requests for pods",
+			CategoryAdapterError,
+		},
+		{
+			"FailedComputeMetricsReplicas",
+			"invalid metrics (1 invalid out of 1), first error is: failed to get external metric queue_depth: unable to fetch metrics from external.metrics.k8s.io/v1beta1",
+			CategoryAdapterError,
+		},
+		{
+			"FailedComputeMetricsReplicas",
+			"invalid metrics (1 invalid out of 1), first error is: failed to get object metric value: unable to get metric requests_per_second: no metrics returned from custom.metrics.k8s.io",
+			CategoryAdapterError,
+		},
+	}
+	for _, test := range tests {
+		event := Event{Reason: test.reason, Message: test.message}
+		classifyEvent(&event)
+		if event.Category != test.want {
+			t.Errorf("%s %q: category %s, want %s", test.reason, test.message, event.Category, test.want)
+		}
+	}
+}
+
+func TestClassifyRescaleEvents(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"New size: 6; reason: cpu resource utilization (percentage of request) above target", CategoryRescaleUp},
+		{"New size: 2; reason: All metrics below target", CategoryRescaleDown},
+		{"New size: 10; reason: Current number of replicas above Spec.MaxReplicas", CategoryRescaleDown},
+		{"New size: 2; reason: Current number of replicas below Spec.MinReplicas", CategoryRescaleUp},
+		{"New size: 1; reason: Current number of replicas must be greater than 0", CategoryRescaleUp},
+		{"New size: 4; reason: something new", CategoryOther},
+	}
+	for _, test := range tests {
+		event := Event{Reason: "SuccessfulRescale", Message: test.message}
+		classifyEvent(&event)
+		if event.Category != test.want {
+			t.Errorf("%q: category %s, want %s", test.message, event.Category, test.want)
+		}
+	}
+}
+
+func TestFillRescaleOrigins(t *testing.T) {
+	rescale := func(timestamp string, count int32, message string) Event {
+		event := Event{Reason: "SuccessfulRescale", Message: message, LastTimestamp: timestamp, Count: count}
+		classifyEvent(&event)
+		return event
+	}
+
+	events := []Event{
+		rescale("2024-01-01T00:10:00Z", 1, "New size: 3; reason: something new"),
+		rescale("2024-01-01T00:00:00Z", 1, "New size: 5; reason: cpu resource utilization (percentage of request) above target"),
+	}
+	fillRescaleOrigins(events)
+	if events[1].OldReplicas != nil {
+		t.Errorf("oldest rescale: oldReplicas %d, want unset", *events[1].OldReplicas)
+	}
+	if events[0].OldReplicas == nil || *events[0].OldReplicas != 5 {
+		t.Errorf("latest rescale: oldReplicas %v, want 5", events[0].OldReplicas)
+	}
+	if events[0].Category != CategoryRescaleDown {
+		t.Errorf("latest rescale: category %s, want %s", events[0].Category, CategoryRescaleDown)
+	}
+
+	ambiguous := [][]Event{
+		{
+			rescale("2024-01-01T00:00:00Z", 3, "New size: 5; reason: All metrics below target"),
+			rescale("2024-01-01T00:10:00Z", 1, "New size: 3; reason: All metrics below target"),
+		},
+		{
+			rescale("2024-01-01T00:00:00Z", 1, "New size: 5; reason: All metrics below target"),
+			rescale("2024-01-01T00:00:00Z", 1, "New size: 3; reason: All metrics below target"),
+		},
+	}
+	for _, events := range ambiguous {
+		fillRescaleOrigins(events)
+		for _, event := range events {
+			if event.OldReplicas != nil {
+				t.Errorf("%s count %d: oldReplicas %d, want unset", event.LastTimestamp, event.Count, *event.OldReplicas)
+			}
+		}
+	}
+}
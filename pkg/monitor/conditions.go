@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Derived statuses used when no condition explains the HPA's state
+const (
+	DerivedStatusHealthy = "Healthy"
+	DerivedStatusUnknown = "Unknown"
+)
+
+// conditionDescriptions are short explanations of condition reasons set by the HPA controller
+var conditionDescriptions = map[string]string{
+	"TooManyReplicas":                  "capped at max",
+	"TooFewReplicas":                   "held at min",
+	"BackoffBoth":                      "recently scaled, holding in both directions",
+	"BackoffDownscale":                 "recently scaled, holding scale down",
+	"ScaleDownStabilized":              "holding scale down for the stabilization window",
+	"ScaleUpStabilized":                "holding scale up for the stabilization window",
+	"ScaleUpLimit":                     "scale up limited by policy",
+	"ScaleDownLimit":                   "scale down limited by policy",
+	"FailedGetScale":                   "cannot read the scale subresource",
+	"FailedUpdateScale":                "cannot update the scale subresource",
+	"FailedGetResourceMetric":          "resource metrics unavailable",
+	"FailedGetContainerResourceMetric": "container resource metrics unavailable",
+	"FailedGetPodsMetric":              "pods metrics unavailable",
+	"FailedGetObjectMetric":            "object metric unavailable",
+	"FailedGetExternalMetric":          "external metric unavailable",
+	"InvalidSelector":                  "invalid or overlapping selector",
+	"InvalidMetricSourceType":          "unsupported metric source",
+	"ScalingDisabled":                  "scaling disabled, target has zero replicas",
+}
+
+// checkScalingConditions reports all HPA conditions, readiness and a derived status
+func (hm *HPAMonitor) checkScalingConditions(hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus) {
+	status.Conditions = make([]Condition, 0, len(hpa.Status.Conditions))
+	for _, condition := range hpa.Status.Conditions {
+		if condition.Type == autoscalingv2.ScalingActive {
+			status.Ready = condition.Status == "True"
+		}
+		status.Conditions = append(status.Conditions, Condition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: hm.formatTimestamp(condition.LastTransitionTime.Time),
+		})
+	}
+	status.DerivedStatus = deriveStatus(hpa.Status.Conditions)
+}
+
+// deriveStatus summarizes conditions into one human-readable status, most severe first:
+// inability to scale, missing metrics, backoff, then replica limits
+func deriveStatus(conditions []autoscalingv2.HorizontalPodAutoscalerCondition) string {
+	if len(conditions) == 0 {
+		return DerivedStatusUnknown
+	}
+
+	byType := make(map[autoscalingv2.HorizontalPodAutoscalerConditionType]autoscalingv2.HorizontalPodAutoscalerCondition)
+	for _, condition := range conditions {
+		byType[condition.Type] = condition
+	}
+
+	if condition, ok := byType[autoscalingv2.AbleToScale]; ok && condition.Status == corev1.ConditionFalse {
+		return describeReason(condition.Reason)
+	}
+	if condition, ok := byType[autoscalingv2.ScalingActive]; ok && condition.Status == corev1.ConditionFalse {
+		return describeReason(condition.Reason)
+	}
+	if condition, ok := byType[autoscalingv2.AbleToScale]; ok {
+		switch condition.Reason {
+		case "BackoffBoth", "BackoffDownscale":
+			return describeReason(condition.Reason)
+		}
+	}
+	if condition, ok := byType[autoscalingv2.ScalingLimited]; ok && condition.Status == corev1.ConditionTrue {
+		return describeReason(condition.Reason)
+	}
+	return DerivedStatusHealthy
+}
+
+// describeReason formats a condition reason with its explanation, e.g. "TooManyReplicas – capped at max"
+func describeReason(reason string) string {
+	if description, ok := conditionDescriptions[reason]; ok {
+		return reason + " – " + description
+	}
+	return reason
+}
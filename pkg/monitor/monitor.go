@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -18,8 +22,16 @@ import (
 
 // HPAMonitor handles HPA monitoring logic
 type HPAMonitor struct {
-	client    kubernetes.Interface
-	tolerance float64
+	client     kubernetes.Interface
+	mu         sync.RWMutex
+	tolerance  float64
+	namespaces []string
+	timeout    time.Duration
+	// runbookTemplate renders per-event runbook links; nil uses the HPA's runbook annotation
+	runbookTemplate *template.Template
+	now        func() time.Time
+	// namespaceCache reuses namespace annotations between polls
+	namespaceCache namespaceCache
 }
 
 // NewHPAMonitor creates a new HPA monitor instance
@@ -27,62 +39,200 @@ func NewHPAMonitor(client kubernetes.Interface) *HPAMonitor {
 	return &HPAMonitor{
 		client:    client,
 		tolerance: 0.1, // 10% tolerance
+		timeout:   10 * time.Second,
+		now:       time.Now,
 	}
 }
 
+// SetClock overrides the clock used for time-based calculations, e.g. during replay
+func (hm *HPAMonitor) SetClock(now func() time.Time) {
+	hm.now = now
+}
+
+// Now returns the current time according to the monitor's clock
+func (hm *HPAMonitor) Now() time.Time {
+	return hm.now()
+}
+
+// StatusOptions narrow ListHPAStatus to part of the cluster
+type StatusOptions struct {
+	// Namespaces limits the HPAs to these namespaces; empty means every monitored namespace
+	Namespaces []string
+	// SkipEvents leaves Events empty instead of listing the events of each HPA
+	SkipEvents bool
+}
+
 // GetHPAStatus retrieves the current status of all HPAs in the cluster
 func (hm *HPAMonitor) GetHPAStatus(ctx context.Context) ([]HPAStatus, error) {
+	return hm.ListHPAStatus(ctx, StatusOptions{})
+}
+
+// ListHPAStatus retrieves the current status of the HPAs selected by opts
+func (hm *HPAMonitor) ListHPAStatus(ctx context.Context, opts StatusOptions) ([]HPAStatus, error) {
 	log := logger.GetLogger()
-	
-	hpaList, err := hm.client.AutoscalingV2().HorizontalPodAutoscalers("").List(ctx, metav1.ListOptions{})
+
+	namespaces, ok := hm.scopeNamespaces(opts.Namespaces)
+	if !ok {
+		return nil, nil
+	}
+	hpas, err := hm.listHPAs(ctx, namespaces)
 	if err != nil {
 		log.WithError(err).Error("Failed to list HPA resources")
 		return nil, err
 	}
 
 	var hpaStatuses []HPAStatus
-	log.WithField("count", len(hpaList.Items)).Info("Processing HPAs")
+	log.WithField("count", len(hpas)).Info("Processing HPAs")
 	
-	for _, hpa := range hpaList.Items {
-		status := hm.buildHPAStatus(&hpa)
+	namespaceAnnotations := hm.namespaceAnnotations(ctx, namespaces)
+	ignored := 0
+	for _, hpa := range hpas {
+		settings := resolveSettings(namespaceAnnotations[hpa.Namespace], hpa.Annotations)
+		if settings.Ignore {
+			ignored++
+			continue
+		}
+		for _, msg := range settings.Errors {
+			log.WithFields(logger.Fields{
+				"namespace": hpa.Namespace,
+				"name":      hpa.Name,
+				"reason":    msg,
+			}).Debug("Ignoring invalid annotation")
+		}
+
+		status := hm.buildHPAStatus(ctx, &hpa, settings, !opts.SkipEvents)
 		hpaStatuses = append(hpaStatuses, status)
 		hm.logHPAStatus(&hpa, &status)
 	}
+	if ignored > 0 {
+		log.WithFields(logger.Fields{
+			"ignored":    ignored,
+			"annotation": AnnotationIgnore,
+		}).Debug("Skipped ignored HPAs")
+	}
 
 	return hpaStatuses, nil
 }
 
-// buildHPAStatus builds HPAStatus from Kubernetes HPA resource
-func (hm *HPAMonitor) buildHPAStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) HPAStatus {
-	status := hm.initializeHPAStatus(hpa)
+// scopeNamespaces returns the requested namespaces that are monitored, or every monitored
+// namespace when none are requested. It reports false when no requested namespace is monitored.
+func (hm *HPAMonitor) scopeNamespaces(requested []string) ([]string, bool) {
+	monitored := hm.GetNamespaces()
+	if len(requested) == 0 {
+		return monitored, true
+	}
+	if len(monitored) == 0 {
+		return requested, true
+	}
+	var scoped []string
+	for _, namespace := range requested {
+		if slices.Contains(monitored, namespace) {
+			scoped = append(scoped, namespace)
+		}
+	}
+	return scoped, len(scoped) > 0
+}
+
+// listHPAs lists HPAs in the given namespaces, or in all namespaces when none are given
+func (hm *HPAMonitor) listHPAs(ctx context.Context, namespaces []string) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var hpas []autoscalingv2.HorizontalPodAutoscaler
+	for _, namespace := range namespaces {
+		callCtx, cancel := hm.callContext(ctx)
+		hpaList, err := hm.client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(callCtx, metav1.ListOptions{})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		hpas = append(hpas, hpaList.Items...)
+	}
+	return hpas, nil
+}
+
+// GetHPA retrieves a single HPA resource by namespace and name
+func (hm *HPAMonitor) GetHPA(ctx context.Context, namespace, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	log := logger.GetLogger()
+	ctx, cancel := hm.callContext(ctx)
+	defer cancel()
+
+	hpa, err := hm.client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.WithFields(logger.Fields{
+			"namespace": namespace,
+			"name":      name,
+		}).WithError(err).Error("Failed to get HPA resource")
+		return nil, err
+	}
+
+	return hpa, nil
+}
+
+// GetHPAStatusByName builds the status of one HPA. HPAs outside the monitored namespaces or
+// ignored by annotation are reported as not found, as GetHPAStatus leaves them out.
+func (hm *HPAMonitor) GetHPAStatusByName(ctx context.Context, namespace, name string) (HPAStatus, error) {
+	notFound := apierrors.NewNotFound(autoscalingv2.Resource("horizontalpodautoscalers"), name)
+	if _, ok := hm.scopeNamespaces([]string{namespace}); !ok {
+		return HPAStatus{}, notFound
+	}
+
+	hpa, err := hm.GetHPA(ctx, namespace, name)
+	if err != nil {
+		return HPAStatus{}, err
+	}
+	settings := resolveSettings(hm.namespaceAnnotations(ctx, []string{namespace})[namespace], hpa.Annotations)
+	if settings.Ignore {
+		return HPAStatus{}, notFound
+	}
+	return hm.buildHPAStatus(ctx, hpa, settings, true), nil
+}
+
+// buildHPAStatus builds HPAStatus from Kubernetes HPA resource, listing its events when withEvents is set
+func (hm *HPAMonitor) buildHPAStatus(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, settings Settings, withEvents bool) HPAStatus {
+	status := hm.initializeHPAStatus(hpa, settings)
 	
 	hm.extractMetrics(hpa, &status)
+	hm.applyBehaviorTolerance(hpa, &status, status.MinReplicas)
 	hm.checkScalingConditions(hpa, &status)
 	hm.setLastScaleTime(hpa, &status)
 	hm.checkScalingStabilization(hpa, &status)
-	hm.fetchEvents(hpa, &status)
+	if withEvents {
+		hm.fetchEvents(ctx, hpa, &status)
+	}
 
 	return status
 }
 
 // initializeHPAStatus initializes basic HPA status fields
-func (hm *HPAMonitor) initializeHPAStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) HPAStatus {
+func (hm *HPAMonitor) initializeHPAStatus(hpa *autoscalingv2.HorizontalPodAutoscaler, settings Settings) HPAStatus {
 	minReplicas := int32(1)
 	if hpa.Spec.MinReplicas != nil {
 		minReplicas = *hpa.Spec.MinReplicas
 	}
+	tolerance := hm.GetTolerance()
+	if settings.Tolerance != nil {
+		tolerance = *settings.Tolerance
+	}
 	
 	return HPAStatus{
 		Name:            hpa.Name,
 		Namespace:       hpa.Namespace,
+		Labels:          hpa.Labels,
 		MinReplicas:     minReplicas,
 		MaxReplicas:     hpa.Spec.MaxReplicas,
 		CurrentReplicas: hpa.Status.CurrentReplicas,
 		DesiredReplicas: hpa.Status.DesiredReplicas,
 		Ready:           len(hpa.Status.Conditions) > 0,
-		Tolerance:       hm.tolerance,
-		ToleranceAdjustedMin: int32(math.Ceil(float64(minReplicas) * (1 - hm.tolerance))),
-		ToleranceAdjustedMax: int32(math.Floor(float64(hpa.Spec.MaxReplicas) * (1 + hm.tolerance))),
+		Tolerance:       tolerance,
+		ToleranceAdjustedMin: int32(math.Ceil(float64(minReplicas) * (1 - tolerance))),
+		ToleranceAdjustedMax: int32(math.Floor(float64(hpa.Spec.MaxReplicas) * (1 + tolerance))),
+		ToleranceSource:      settings.ToleranceSource,
+		Owner:                settings.Owner,
+		Runbook:              settings.Runbook,
+		AlertMaxDuration:     settings.AlertMaxDuration,
+		AnnotationErrors:     settings.Errors,
 	}
 }
 
@@ -169,15 +319,6 @@ func (hm *HPAMonitor) finalizeMetrics(status *HPAStatus) {
 	}
 }
 
-// checkScalingConditions checks HPA conditions for readiness
-func (hm *HPAMonitor) checkScalingConditions(hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus) {
-	for _, condition := range hpa.Status.Conditions {
-		if condition.Type == autoscalingv2.ScalingActive {
-			status.Ready = condition.Status == "True"
-		}
-	}
-}
-
 // setLastScaleTime sets the last scale time in the status
 func (hm *HPAMonitor) setLastScaleTime(hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus) {
 	if hpa.Status.LastScaleTime != nil {
@@ -188,7 +329,7 @@ func (hm *HPAMonitor) setLastScaleTime(hpa *autoscalingv2.HorizontalPodAutoscale
 
 // checkScalingStabilization checks if scaling is stabilized
 func (hm *HPAMonitor) checkScalingStabilization(hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus) {
-	now := time.Now()
+	now := hm.now()
 	if hpa.Status.LastScaleTime != nil {
 		timeSinceLastScale := now.Sub(hpa.Status.LastScaleTime.Time)
 		status.ScaleUpStabilized = timeSinceLastScale > 3*time.Minute
@@ -204,7 +345,9 @@ func (hm *HPAMonitor) SetTolerance(tolerance float64) {
 	log := logger.GetLogger()
 	
 	if tolerance >= 0.0 && tolerance <= 1.0 {
+		hm.mu.Lock()
 		hm.tolerance = tolerance
+		hm.mu.Unlock()
 		log.WithFields(logger.Fields{
 			"tolerance": tolerance,
 			"percentage": tolerance * 100,
@@ -216,13 +359,60 @@ func (hm *HPAMonitor) SetTolerance(tolerance float64) {
 
 // GetTolerance returns the current tolerance setting
 func (hm *HPAMonitor) GetTolerance() float64 {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
 	return hm.tolerance
 }
 
+// SetNamespaces restricts monitoring to the given namespaces; empty means all namespaces
+func (hm *HPAMonitor) SetNamespaces(namespaces []string) {
+	log := logger.GetLogger()
+
+	hm.mu.Lock()
+	hm.namespaces = append([]string(nil), namespaces...)
+	hm.mu.Unlock()
+
+	log.WithField("namespaces", namespaces).Info("Namespace scope updated")
+}
+
+// GetNamespaces returns the namespaces being monitored; empty means all namespaces
+func (hm *HPAMonitor) GetNamespaces() []string {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return append([]string(nil), hm.namespaces...)
+}
+
+// SetTimeout sets the timeout applied to each Kubernetes API call
+func (hm *HPAMonitor) SetTimeout(timeout time.Duration) {
+	log := logger.GetLogger()
+
+	hm.mu.Lock()
+	hm.timeout = timeout
+	hm.mu.Unlock()
+
+	log.WithField("timeout", timeout.String()).Info("API call timeout updated")
+}
+
+// SetRunbookTemplate sets the template used for event runbook links; nil disables it
+func (hm *HPAMonitor) SetRunbookTemplate(tmpl *template.Template) {
+	hm.mu.Lock()
+	hm.runbookTemplate = tmpl
+	hm.mu.Unlock()
+}
+
+// callContext derives a context bounded by the per-call timeout
+func (hm *HPAMonitor) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	hm.mu.RLock()
+	timeout := hm.timeout
+	hm.mu.RUnlock()
+	return context.WithTimeout(ctx, timeout)
+}
+
 // fetchEvents fetches events related to the HPA
-func (hm *HPAMonitor) fetchEvents(hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus) {
+func (hm *HPAMonitor) fetchEvents(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus) {
 	log := logger.GetLogger()
-	ctx := context.Background()
+	ctx, cancel := hm.callContext(ctx)
+	defer cancel()
 	
 	events, err := hm.client.CoreV1().Events(hpa.Namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: "involvedObject.name=" + hpa.Name,
@@ -244,8 +434,16 @@ func (hm *HPAMonitor) fetchEvents(hpa *autoscalingv2.HorizontalPodAutoscaler, st
 
 	var hpaEvents []Event
 	for _, event := range events.Items {
-		hpaEvents = append(hpaEvents, hm.convertKubernetesEvent(event))
+		// Fake clients used for replay ignore field selectors
+		if event.InvolvedObject.Name != hpa.Name {
+			continue
+		}
+		hpaEvent := hm.convertKubernetesEvent(event)
+		classifyEvent(&hpaEvent)
+		hpaEvent.RunbookURL = hm.runbookURL(status, &hpaEvent)
+		hpaEvents = append(hpaEvents, hpaEvent)
 	}
+	fillRescaleOrigins(hpaEvents)
 
 	status.Events = hpaEvents
 }
@@ -421,8 +619,8 @@ func calculateRatio(status *HPAStatus) *float64 {
 		}
 		
 		// Try advanced parsing for other metric types
-		currentValue := parseMetricValue(current)
-		targetValue := parseMetricValue(target)
+		currentValue := ParseMetricValue(current)
+		targetValue := ParseMetricValue(target)
 		
 		if currentValue != nil && targetValue != nil && *targetValue > 0 {
 			ratio := *currentValue / *targetValue
@@ -433,8 +631,8 @@ func calculateRatio(status *HPAStatus) *float64 {
 	return nil
 }
 
-// parseMetricValue parses a metric value string and returns the numeric value
-func parseMetricValue(value string) *float64 {
+// ParseMetricValue parses a metric value string and returns the numeric value
+func ParseMetricValue(value string) *float64 {
 	// Remove common units and parse
 	value = strings.TrimSpace(value)
 	
@@ -0,0 +1,44 @@
+package monitor
+
+import (
+	"math"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// ToleranceSourceBehavior marks a tolerance set in spec.behavior, which the controller uses
+// over the cluster-wide tolerance
+const ToleranceSourceBehavior = "behavior"
+
+// BehaviorTolerance returns the tolerance set on scaling rules, if any. The field requires
+// the HPAConfigurableTolerance feature; older API servers never return it.
+func BehaviorTolerance(rules *autoscalingv2.HPAScalingRules) (float64, bool) {
+	if rules == nil || rules.Tolerance == nil {
+		return 0, false
+	}
+	return float64(rules.Tolerance.MilliValue()) / 1000, true
+}
+
+// applyBehaviorTolerance resolves the per-direction tolerances and sets the effective
+// tolerance for the direction the ratio points in
+func (hm *HPAMonitor) applyBehaviorTolerance(hpa *autoscalingv2.HorizontalPodAutoscaler, status *HPAStatus, minReplicas int32) {
+	status.ScaleUpTolerance, status.ScaleUpToleranceSource = status.Tolerance, status.ToleranceSource
+	status.ScaleDownTolerance, status.ScaleDownToleranceSource = status.Tolerance, status.ToleranceSource
+
+	if hpa.Spec.Behavior != nil {
+		if tolerance, ok := BehaviorTolerance(hpa.Spec.Behavior.ScaleUp); ok {
+			status.ScaleUpTolerance, status.ScaleUpToleranceSource = tolerance, ToleranceSourceBehavior
+		}
+		if tolerance, ok := BehaviorTolerance(hpa.Spec.Behavior.ScaleDown); ok {
+			status.ScaleDownTolerance, status.ScaleDownToleranceSource = tolerance, ToleranceSourceBehavior
+		}
+	}
+
+	if status.Ratio != nil && *status.Ratio < 1.0 {
+		status.Tolerance, status.ToleranceSource = status.ScaleDownTolerance, status.ScaleDownToleranceSource
+	} else {
+		status.Tolerance, status.ToleranceSource = status.ScaleUpTolerance, status.ScaleUpToleranceSource
+	}
+	status.ToleranceAdjustedMin = int32(math.Ceil(float64(minReplicas) * (1 - status.ScaleDownTolerance)))
+	status.ToleranceAdjustedMax = int32(math.Floor(float64(hpa.Spec.MaxReplicas) * (1 + status.ScaleUpTolerance)))
+}
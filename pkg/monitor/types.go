@@ -4,6 +4,7 @@ package monitor
 type HPAStatus struct {
 	Name                    string  `json:"name"`
 	Namespace               string  `json:"namespace"`
+	Labels                  map[string]string `json:"labels,omitempty"`
 	MinReplicas             int32   `json:"minReplicas"`
 	MaxReplicas             int32   `json:"maxReplicas"`
 	CurrentReplicas         int32   `json:"currentReplicas"`
@@ -17,13 +18,33 @@ type HPAStatus struct {
 	Tolerance               float64 `json:"tolerance"`
 	ToleranceAdjustedMin    int32   `json:"toleranceAdjustedMin"`
 	ToleranceAdjustedMax    int32   `json:"toleranceAdjustedMax"`
+	ToleranceSource         string   `json:"toleranceSource"`
+	ScaleUpTolerance        float64  `json:"scaleUpTolerance"`
+	ScaleUpToleranceSource  string   `json:"scaleUpToleranceSource"`
+	ScaleDownTolerance      float64  `json:"scaleDownTolerance"`
+	ScaleDownToleranceSource string  `json:"scaleDownToleranceSource"`
+	Owner                   string   `json:"owner,omitempty"`
+	Runbook                 string   `json:"runbook,omitempty"`
+	AlertMaxDuration        string   `json:"alertMaxDuration,omitempty"`
+	AnnotationErrors        []string `json:"annotationErrors,omitempty"`
 	LastScaleTime           *string `json:"lastScaleTime"`
 	Ready                   bool    `json:"ready"`
+	Conditions              []Condition `json:"conditions"`
+	DerivedStatus           string      `json:"derivedStatus"`
 	ScaleUpStabilized       bool    `json:"scaleUpStabilized"`
 	ScaleDownStabilized     bool    `json:"scaleDownStabilized"`
 	Events                  []Event `json:"events"`
 }
 
+// Condition represents an HPA status condition
+type Condition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
 // Event represents a Kubernetes event
 type Event struct {
 	Type           string `json:"type"`
@@ -32,4 +53,10 @@ type Event struct {
 	FirstTimestamp string `json:"firstTimestamp"`
 	LastTimestamp  string `json:"lastTimestamp"`
 	Count          int32  `json:"count"`
+	Category       string `json:"category"`
+	Hint           string `json:"hint,omitempty"`
+	RunbookURL     string `json:"runbookURL,omitempty"`
+	OldReplicas    *int32 `json:"oldReplicas,omitempty"`
+	NewReplicas    *int32 `json:"newReplicas,omitempty"`
+	TriggerMetric  string `json:"triggerMetric,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,256 @@
+// Package openapi builds OpenAPI 3 documents, deriving schemas from Go types by
+// reflection so they follow the JSON encoding of the structs they describe.
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.0.3"
+
+// Schema is a JSON schema object
+type Schema map[string]interface{}
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	typeNames map[reflect.Type]string
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the named schemas referenced from operations
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+// PathItem maps lowercase HTTP methods to operations
+type PathItem map[string]*Operation
+
+// Operation describes one method on one path
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Deprecated  bool                `json:"deprecated,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+	// Messages documents the JSON messages of a WebSocket or event stream endpoint
+	Messages *Messages `json:"x-messages,omitempty"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema"`
+}
+
+// RequestBody is a JSON request body
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response with an optional body
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Messages lists the schemas of messages sent by the server and by the client
+type Messages struct {
+	Server []Schema `json:"server,omitempty"`
+	Client []Schema `json:"client,omitempty"`
+}
+
+// New creates an empty document
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: make(map[string]Schema)},
+		typeNames:  make(map[reflect.Type]string),
+	}
+}
+
+// Add registers an operation. Paths use OpenAPI syntax, e.g. /hpas/{name}.
+func (d *Document) Add(method, path string, op *Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+// Has reports whether an operation is registered for the method and path
+func (d *Document) Has(method, path string) bool {
+	_, ok := d.Paths[path][strings.ToLower(method)]
+	return ok
+}
+
+// Routes returns the registered operations as "METHOD path", sorted
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, item := range d.Paths {
+		for method := range item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// JSON returns a response or request body schema of the given value's type
+func (d *Document) JSON(value interface{}) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: d.SchemaOf(value)}}
+}
+
+// SchemaOf returns the schema of the given value's type; named structs are added
+// to the components and referenced
+func (d *Document) SchemaOf(value interface{}) Schema {
+	return d.schema(reflect.TypeOf(value))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema returns the schema of a type
+func (d *Document) schema(t reflect.Type) Schema {
+	if t == nil {
+		return Schema{}
+	}
+	if t == timeType {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := d.schema(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return Schema{"allOf": []Schema{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return Schema{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return Schema{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return Schema{"type": "number", "format": "double"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "format": "byte"}
+		}
+		return Schema{"type": "array", "items": d.schema(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": d.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		return d.ref(t)
+	}
+	// interface{} and anything else accepts any value
+	return Schema{}
+}
+
+// Name sets the component name of the value's type, for Go names that are unclear
+// without their package such as Status. Call it before the type is first used.
+func (d *Document) Name(value interface{}, name string) {
+	d.typeNames[reflect.TypeOf(value)] = name
+}
+
+// ref adds a named struct to the components once and returns a reference to it
+func (d *Document) ref(t reflect.Type) Schema {
+	name, ok := d.typeNames[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := d.Components.Schemas[name]; taken {
+			// Qualify with the package name, e.g. replay.Status becomes ReplayStatus
+			pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+		}
+		d.typeNames[t] = name
+	}
+	if _, built := d.Components.Schemas[name]; !built {
+		// Reserve the name before recursing so self-referencing types terminate
+		d.Components.Schemas[name] = Schema{}
+		d.Components.Schemas[name] = d.structSchema(t)
+	}
+	return Schema{"$ref": "#/components/schemas/" + name}
+}
+
+// structSchema describes the JSON object encoding/json produces for a struct
+func (d *Document) structSchema(t reflect.Type) Schema {
+	properties := make(map[string]Schema)
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// Embedded structs without a name are flattened into the parent
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := d.structSchema(field.Type)
+			for key, value := range embedded["properties"].(map[string]Schema) {
+				properties[key] = value
+			}
+			if fields, ok := embedded["required"].([]string); ok {
+				required = append(required, fields...)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = d.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: hpamonitor/v1/hpa_monitor.proto
+
+package hpamonitorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HPAMonitorService_ListHPAs_FullMethodName   = "/hpamonitor.v1.HPAMonitorService/ListHPAs"
+	HPAMonitorService_GetHPA_FullMethodName     = "/hpamonitor.v1.HPAMonitorService/GetHPA"
+	HPAMonitorService_WatchHPAs_FullMethodName  = "/hpamonitor.v1.HPAMonitorService/WatchHPAs"
+	HPAMonitorService_ListAlerts_FullMethodName = "/hpamonitor.v1.HPAMonitorService/ListAlerts"
+)
+
+// HPAMonitorServiceClient is the client API for HPAMonitorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HPAMonitorService serves the HPA statuses of the HTTP API over gRPC.
+type HPAMonitorServiceClient interface {
+	// ListHPAs returns a filtered, sorted page of HPAs, like GET /api/v1/hpas.
+	ListHPAs(ctx context.Context, in *ListHPAsRequest, opts ...grpc.CallOption) (*ListHPAsResponse, error)
+	// GetHPA returns the full status of one HPA.
+	GetHPA(ctx context.Context, in *GetHPARequest, opts ...grpc.CallOption) (*GetHPAResponse, error)
+	// WatchHPAs sends a snapshot, then patches every interval and scale
+	// transitions as they happen, like the hpa-monitor.v2 WebSocket protocol.
+	WatchHPAs(ctx context.Context, in *WatchHPAsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchHPAsResponse], error)
+	// ListAlerts returns the HPAs whose derived status is not healthy.
+	ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error)
+}
+
+type hPAMonitorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHPAMonitorServiceClient(cc grpc.ClientConnInterface) HPAMonitorServiceClient {
+	return &hPAMonitorServiceClient{cc}
+}
+
+func (c *hPAMonitorServiceClient) ListHPAs(ctx context.Context, in *ListHPAsRequest, opts ...grpc.CallOption) (*ListHPAsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHPAsResponse)
+	err := c.cc.Invoke(ctx, HPAMonitorService_ListHPAs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hPAMonitorServiceClient) GetHPA(ctx context.Context, in *GetHPARequest, opts ...grpc.CallOption) (*GetHPAResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHPAResponse)
+	err := c.cc.Invoke(ctx, HPAMonitorService_GetHPA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hPAMonitorServiceClient) WatchHPAs(ctx context.Context, in *WatchHPAsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchHPAsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HPAMonitorService_ServiceDesc.Streams[0], HPAMonitorService_WatchHPAs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchHPAsRequest, WatchHPAsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HPAMonitorService_WatchHPAsClient = grpc.ServerStreamingClient[WatchHPAsResponse]
+
+func (c *hPAMonitorServiceClient) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertsResponse)
+	err := c.cc.Invoke(ctx, HPAMonitorService_ListAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HPAMonitorServiceServer is the server API for HPAMonitorService service.
+// All implementations must embed UnimplementedHPAMonitorServiceServer
+// for forward compatibility.
+//
+// HPAMonitorService serves the HPA statuses of the HTTP API over gRPC.
+type HPAMonitorServiceServer interface {
+	// ListHPAs returns a filtered, sorted page of HPAs, like GET /api/v1/hpas.
+	ListHPAs(context.Context, *ListHPAsRequest) (*ListHPAsResponse, error)
+	// GetHPA returns the full status of one HPA.
+	GetHPA(context.Context, *GetHPARequest) (*GetHPAResponse, error)
+	// WatchHPAs sends a snapshot, then patches every interval and scale
+	// transitions as they happen, like the hpa-monitor.v2 WebSocket protocol.
+	WatchHPAs(*WatchHPAsRequest, grpc.ServerStreamingServer[WatchHPAsResponse]) error
+	// ListAlerts returns the HPAs whose derived status is not healthy.
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
+	mustEmbedUnimplementedHPAMonitorServiceServer()
+}
+
+// UnimplementedHPAMonitorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHPAMonitorServiceServer struct{}
+
+func (UnimplementedHPAMonitorServiceServer) ListHPAs(context.Context, *ListHPAsRequest) (*ListHPAsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHPAs not implemented")
+}
+func (UnimplementedHPAMonitorServiceServer) GetHPA(context.Context, *GetHPARequest) (*GetHPAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHPA not implemented")
+}
+func (UnimplementedHPAMonitorServiceServer) WatchHPAs(*WatchHPAsRequest, grpc.ServerStreamingServer[WatchHPAsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchHPAs not implemented")
+}
+func (UnimplementedHPAMonitorServiceServer) ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (UnimplementedHPAMonitorServiceServer) mustEmbedUnimplementedHPAMonitorServiceServer() {}
+func (UnimplementedHPAMonitorServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeHPAMonitorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HPAMonitorServiceServer will
+// result in compilation errors.
+type UnsafeHPAMonitorServiceServer interface {
+	mustEmbedUnimplementedHPAMonitorServiceServer()
+}
+
+func RegisterHPAMonitorServiceServer(s grpc.ServiceRegistrar, srv HPAMonitorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHPAMonitorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HPAMonitorService_ServiceDesc, srv)
+}
+
+func _HPAMonitorService_ListHPAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHPAsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPAMonitorServiceServer).ListHPAs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPAMonitorService_ListHPAs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPAMonitorServiceServer).ListHPAs(ctx, req.(*ListHPAsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HPAMonitorService_GetHPA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHPARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPAMonitorServiceServer).GetHPA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPAMonitorService_GetHPA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPAMonitorServiceServer).GetHPA(ctx, req.(*GetHPARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HPAMonitorService_WatchHPAs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchHPAsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HPAMonitorServiceServer).WatchHPAs(m, &grpc.GenericServerStream[WatchHPAsRequest, WatchHPAsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HPAMonitorService_WatchHPAsServer = grpc.ServerStreamingServer[WatchHPAsResponse]
+
+func _HPAMonitorService_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPAMonitorServiceServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPAMonitorService_ListAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPAMonitorServiceServer).ListAlerts(ctx, req.(*ListAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HPAMonitorService_ServiceDesc is the grpc.ServiceDesc for HPAMonitorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HPAMonitorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hpamonitor.v1.HPAMonitorService",
+	HandlerType: (*HPAMonitorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListHPAs",
+			Handler:    _HPAMonitorService_ListHPAs_Handler,
+		},
+		{
+			MethodName: "GetHPA",
+			Handler:    _HPAMonitorService_GetHPA_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _HPAMonitorService_ListAlerts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchHPAs",
+			Handler:       _HPAMonitorService_WatchHPAs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hpamonitor/v1/hpa_monitor.proto",
+}
@@ -0,0 +1,215 @@
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"hpa-monitor/pkg/history"
+)
+
+// Confidence describes how much recorded data backs a recommendation
+type Confidence string
+
+const (
+	ConfidenceLow    Confidence = "low"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceHigh   Confidence = "high"
+)
+
+const (
+	// minSamples is the number of samples required before recommending anything
+	minSamples = 10
+	// atMaxThreshold is the share of time at maxReplicas that triggers a raise
+	atMaxThreshold = 0.05
+	// maxTargetUtilization caps suggested utilization targets
+	maxTargetUtilization = 80.0
+)
+
+// Recommendation is a single suggested change to an HPA spec
+type Recommendation struct {
+	Field      string     `json:"field"`
+	Current    float64    `json:"current"`
+	Suggested  float64    `json:"suggested"`
+	Message    string     `json:"message"`
+	Evidence   []string   `json:"evidence"`
+	Confidence Confidence `json:"confidence"`
+}
+
+// Report holds the recommendations for one HPA together with the data window used
+type Report struct {
+	Namespace       string           `json:"namespace"`
+	Name            string           `json:"name"`
+	Samples         int              `json:"samples"`
+	WindowStart     *string          `json:"windowStart"`
+	WindowEnd       *string          `json:"windowEnd"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// Analyze builds recommendations for an HPA from its recorded history
+func Analyze(namespace, name string, samples []history.Sample) Report {
+	report := Report{
+		Namespace:       namespace,
+		Name:            name,
+		Samples:         len(samples),
+		Recommendations: []Recommendation{},
+	}
+	if len(samples) == 0 {
+		return report
+	}
+
+	start := samples[0].Timestamp.Format(time.RFC3339)
+	end := samples[len(samples)-1].Timestamp.Format(time.RFC3339)
+	report.WindowStart = &start
+	report.WindowEnd = &end
+
+	if len(samples) < minSamples {
+		return report
+	}
+
+	span := samples[len(samples)-1].Timestamp.Sub(samples[0].Timestamp)
+	confidence := confidenceFor(span)
+	latest := samples[len(samples)-1]
+
+	if rec := recommendMinReplicas(samples, latest, span, confidence); rec != nil {
+		report.Recommendations = append(report.Recommendations, *rec)
+	}
+	if rec := recommendMaxReplicas(samples, latest, span, confidence); rec != nil {
+		report.Recommendations = append(report.Recommendations, *rec)
+	}
+	if rec := recommendTargetUtilization(samples, latest, span, confidence); rec != nil {
+		report.Recommendations = append(report.Recommendations, *rec)
+	}
+
+	return report
+}
+
+// recommendMinReplicas suggests raising minReplicas when replicas rarely drop to it
+func recommendMinReplicas(samples []history.Sample, latest history.Sample, span time.Duration, confidence Confidence) *Recommendation {
+	replicas := replicaValues(samples)
+	p5 := math.Floor(history.Percentile(replicas, 5))
+	if p5 <= float64(latest.MinReplicas) {
+		return nil
+	}
+
+	return &Recommendation{
+		Field:     "minReplicas",
+		Current:   float64(latest.MinReplicas),
+		Suggested: p5,
+		Message:   fmt.Sprintf("minReplicas could be %.0f (p5 of replicas over %s)", p5, formatSpan(span)),
+		Evidence: []string{
+			fmt.Sprintf("p5 replicas: %.0f", p5),
+			fmt.Sprintf("lowest replicas: %.0f", history.Percentile(replicas, 0)),
+			fmt.Sprintf("samples: %d over %s", len(samples), formatSpan(span)),
+		},
+		Confidence: confidence,
+	}
+}
+
+// recommendMaxReplicas suggests raising maxReplicas when the HPA is frequently capped
+func recommendMaxReplicas(samples []history.Sample, latest history.Sample, span time.Duration, confidence Confidence) *Recommendation {
+	atMax := 0
+	for _, sample := range samples {
+		if sample.MaxReplicas > 0 && sample.CurrentReplicas >= sample.MaxReplicas {
+			atMax++
+		}
+	}
+
+	share := float64(atMax) / float64(len(samples))
+	if share < atMaxThreshold {
+		return nil
+	}
+
+	suggested := math.Ceil(float64(latest.MaxReplicas) * 1.5)
+	return &Recommendation{
+		Field:     "maxReplicas",
+		Current:   float64(latest.MaxReplicas),
+		Suggested: suggested,
+		Message:   fmt.Sprintf("maxReplicas was hit %.0f%% of the time, consider %.0f", share*100, suggested),
+		Evidence: []string{
+			fmt.Sprintf("samples at max: %d of %d", atMax, len(samples)),
+			fmt.Sprintf("p95 replicas: %.0f", history.Percentile(replicaValues(samples), 95)),
+			fmt.Sprintf("window: %s", formatSpan(span)),
+		},
+		Confidence: confidence,
+	}
+}
+
+// recommendTargetUtilization suggests a higher utilization target when usage stays well below it
+func recommendTargetUtilization(samples []history.Sample, latest history.Sample, span time.Duration, confidence Confidence) *Recommendation {
+	if !latest.Utilization || latest.MetricTarget == nil || *latest.MetricTarget <= 0 {
+		return nil
+	}
+	target := *latest.MetricTarget
+
+	var values []float64
+	for _, sample := range samples {
+		if sample.Utilization && sample.MetricCurrent != nil {
+			values = append(values, *sample.MetricCurrent)
+		}
+	}
+	if len(values) < minSamples {
+		return nil
+	}
+
+	avg := mean(values)
+	if avg >= target*0.5 || target >= maxTargetUtilization {
+		return nil
+	}
+
+	suggested := math.Min(target+20, maxTargetUtilization)
+	return &Recommendation{
+		Field:     "targetUtilization",
+		Current:   target,
+		Suggested: suggested,
+		Message: fmt.Sprintf("target %s %.0f%% keeps average utilization at %.0f%%, consider %.0f%%",
+			latest.MetricName, target, avg, suggested),
+		Evidence: []string{
+			fmt.Sprintf("average utilization: %.1f%%", avg),
+			fmt.Sprintf("p95 utilization: %.1f%%", history.Percentile(values, 95)),
+			fmt.Sprintf("samples: %d over %s", len(values), formatSpan(span)),
+		},
+		Confidence: confidence,
+	}
+}
+
+// confidenceFor grades confidence by how much time the samples cover
+func confidenceFor(span time.Duration) Confidence {
+	switch {
+	case span >= 7*24*time.Hour:
+		return ConfidenceHigh
+	case span >= 24*time.Hour:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+// replicaValues extracts current replica counts as floats
+func replicaValues(samples []history.Sample) []float64 {
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = float64(sample.CurrentReplicas)
+	}
+	return values
+}
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// formatSpan renders a duration as days or hours for messages
+func formatSpan(span time.Duration) string {
+	if days := int(span.Hours() / 24); days >= 1 {
+		return fmt.Sprintf("%d days", days)
+	}
+	if hours := int(span.Hours()); hours >= 1 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d minutes", int(span.Minutes()))
+}
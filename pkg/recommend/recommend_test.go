@@ -0,0 +1,149 @@
+package recommend
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"hpa-monitor/pkg/history"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// series returns one sample per interval with the replicas and CPU utilization for each
+// index; a negative utilization leaves the metric unset
+func series(interval time.Duration, minReplicas, maxReplicas int32, target float64, replicas func(i int) int32, utilization func(i int) float64, count int) []history.Sample {
+	samples := make([]history.Sample, count)
+	for i := range samples {
+		samples[i] = history.Sample{
+			Timestamp:       start.Add(time.Duration(i) * interval),
+			MinReplicas:     minReplicas,
+			MaxReplicas:     maxReplicas,
+			CurrentReplicas: replicas(i),
+			MetricName:      "cpu",
+			Utilization:     true,
+			MetricTarget:    &target,
+		}
+		if value := utilization(i); value >= 0 {
+			samples[i].MetricCurrent = &value
+		}
+	}
+	return samples
+}
+
+func constant[T int32 | float64](value T) func(int) T {
+	return func(int) T { return value }
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []history.Sample
+		// want lists the recommendations as "field current->suggested"
+		want       []string
+		confidence Confidence
+	}{
+		{
+			name:    "too few samples",
+			samples: series(time.Minute, 1, 10, 50, constant[int32](10), constant(10.0), minSamples-1),
+		},
+		{
+			name:    "minReplicas raised to the p5 of replicas",
+			samples: series(time.Minute, 1, 20, 50, func(i int) int32 { return int32(4 + i%10) }, constant(40.0), 20),
+			want:    []string{"minReplicas 1->4"},
+		},
+		{
+			// With 20 samples the p5 is the lowest one
+			name: "minReplicas kept when replicas reach it in 5% of samples",
+			samples: series(time.Minute, 2, 20, 50, func(i int) int32 {
+				if i == 0 {
+					return 2
+				}
+				return 6
+			}, constant(40.0), 20),
+		},
+		{
+			name: "maxReplicas raised 1.5x when at max for 5% of samples",
+			samples: series(time.Minute, 1, 9, 50, func(i int) int32 {
+				if i == 0 {
+					return 9
+				}
+				return 1
+			}, constant(40.0), 20),
+			want: []string{"maxReplicas 9->14"},
+		},
+		{
+			name: "maxReplicas kept when at max for less than 5% of samples",
+			samples: series(time.Minute, 1, 9, 50, func(i int) int32 {
+				if i == 0 {
+					return 9
+				}
+				return 1
+			}, constant(40.0), 21),
+		},
+		{
+			name:    "target raised by 20 when utilization stays below half of it",
+			samples: series(time.Minute, 1, 10, 50, constant[int32](1), constant(20.0), 20),
+			want:    []string{"targetUtilization 50->70"},
+		},
+		{
+			name:    "target raise capped at 80",
+			samples: series(time.Minute, 1, 10, 70, constant[int32](1), constant(20.0), 20),
+			want:    []string{"targetUtilization 70->80"},
+		},
+		{
+			name:    "target kept when utilization reaches half of it",
+			samples: series(time.Minute, 1, 10, 50, constant[int32](1), constant(25.0), 20),
+		},
+		{
+			name:    "target kept at 80 or above",
+			samples: series(time.Minute, 1, 10, 80, constant[int32](1), constant(10.0), 20),
+		},
+		{
+			name: "target kept with too few utilization samples",
+			samples: series(time.Minute, 1, 10, 50, constant[int32](1), func(i int) float64 {
+				if i < minSamples-1 {
+					return 10
+				}
+				return -1
+			}, 20),
+		},
+		{
+			name:       "a week of samples has high confidence",
+			samples:    series(12*time.Hour, 1, 10, 50, constant[int32](1), constant(20.0), 16),
+			want:       []string{"targetUtilization 50->70"},
+			confidence: ConfidenceHigh,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := Analyze("shop", "checkout", test.samples)
+			if report.Samples != len(test.samples) || report.WindowStart == nil || report.WindowEnd == nil {
+				t.Errorf("report %+v, want %d samples and a window", report, len(test.samples))
+			}
+
+			var got []string
+			for _, rec := range report.Recommendations {
+				got = append(got, rec.Field+" "+strconv.FormatFloat(rec.Current, 'f', -1, 64)+"->"+strconv.FormatFloat(rec.Suggested, 'f', -1, 64))
+				want := test.confidence
+				if want == "" {
+					want = ConfidenceLow
+				}
+				if rec.Confidence != want {
+					t.Errorf("%s confidence %s, want %s", rec.Field, rec.Confidence, want)
+				}
+			}
+			if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+				t.Errorf("recommendations %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeWithoutSamples(t *testing.T) {
+	report := Analyze("shop", "checkout", nil)
+	if report.WindowStart != nil || report.WindowEnd != nil || len(report.Recommendations) != 0 {
+		t.Errorf("report %+v, want no window and no recommendations", report)
+	}
+}
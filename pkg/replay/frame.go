@@ -0,0 +1,71 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+// maxLineSize bounds a single NDJSON frame; large clusters produce big frames
+const maxLineSize = 64 * 1024 * 1024
+
+// Frame is one recorded snapshot of the cluster objects the monitor reads
+type Frame struct {
+	Time         time.Time                               `json:"time"`
+	HPAs         []autoscalingv2.HorizontalPodAutoscaler `json:"hpas"`
+	Events       []v1.Event                              `json:"events"`
+	Deployments  []appsv1.Deployment                     `json:"deployments,omitempty"`
+	StatefulSets []appsv1.StatefulSet                    `json:"statefulSets,omitempty"`
+}
+
+// ReadFrames reads NDJSON frames from r, sorted by time
+func ReadFrames(r io.Reader) ([]Frame, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), maxLineSize)
+
+	var frames []Frame
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var frame Frame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		frames = append(frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(frames, func(i, j int) bool { return frames[i].Time.Before(frames[j].Time) })
+	return frames, nil
+}
+
+// LoadFile reads all frames from an NDJSON recording
+func LoadFile(path string) ([]Frame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %v", err)
+	}
+	defer file.Close()
+
+	frames, err := ReadFrames(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %v", path, err)
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("recording %s contains no frames", path)
+	}
+	return frames, nil
+}
@@ -0,0 +1,291 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"hpa-monitor/pkg/logger"
+)
+
+// tickInterval is how often the playback clock advances
+const tickInterval = 250 * time.Millisecond
+
+var (
+	hpaResource         = autoscalingv2.SchemeGroupVersion.WithResource("horizontalpodautoscalers")
+	eventResource       = v1.SchemeGroupVersion.WithResource("events")
+	deploymentResource  = appsv1.SchemeGroupVersion.WithResource("deployments")
+	statefulSetResource = appsv1.SchemeGroupVersion.WithResource("statefulsets")
+)
+
+// Status describes the current playback position
+type Status struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Position time.Time `json:"position"`
+	Frame    int       `json:"frame"`
+	Frames   int       `json:"frames"`
+	Speed    float64   `json:"speed"`
+	Paused   bool      `json:"paused"`
+}
+
+// Player drives an in-process fake cluster from recorded frames
+type Player struct {
+	mu       sync.Mutex
+	client   *fake.Clientset
+	frames   []Frame
+	index    int
+	position time.Time
+	speed    float64
+	paused   bool
+}
+
+// NewPlayer creates a player positioned at the first frame
+func NewPlayer(frames []Frame, speed float64) (*Player, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("recording contains no frames")
+	}
+	if speed <= 0 {
+		return nil, fmt.Errorf("playback speed must be positive, got %g", speed)
+	}
+
+	p := &Player{
+		client:   fake.NewSimpleClientset(),
+		frames:   frames,
+		index:    -1,
+		position: frames[0].Time,
+		speed:    speed,
+	}
+	if err := p.apply(0); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Client returns the fake Kubernetes client backed by the recording
+func (p *Player) Client() kubernetes.Interface {
+	return p.client
+}
+
+// Now returns the playback clock
+func (p *Player) Now() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.position
+}
+
+// Status returns the current playback state
+func (p *Player) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Status{
+		Start:    p.frames[0].Time,
+		End:      p.frames[len(p.frames)-1].Time,
+		Position: p.position,
+		Frame:    p.index,
+		Frames:   len(p.frames),
+		Speed:    p.speed,
+		Paused:   p.paused,
+	}
+}
+
+// SetSpeed changes the playback speed multiplier
+func (p *Player) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return fmt.Errorf("playback speed must be positive, got %g", speed)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.speed = speed
+	return nil
+}
+
+// SetPaused pauses or resumes playback
+func (p *Player) SetPaused(paused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused = paused
+}
+
+// Seek moves playback to the given time, clamped to the recording
+func (p *Player) Seek(t time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	start, end := p.frames[0].Time, p.frames[len(p.frames)-1].Time
+	if t.Before(start) {
+		t = start
+	}
+	if t.After(end) {
+		t = end
+	}
+	p.position = t
+	return p.applyLocked(p.frameAt(t))
+}
+
+// Run advances the playback clock until the context is cancelled
+func (p *Player) Run(ctx context.Context) {
+	log := logger.GetLogger()
+	status := p.Status()
+	log.WithFields(logger.Fields{
+		"frames": status.Frames,
+		"start":  status.Start.Format(time.RFC3339),
+		"end":    status.End.Format(time.RFC3339),
+		"speed":  status.Speed,
+	}).Info("Replay started")
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Replay stopped")
+			return
+		case now := <-ticker.C:
+			p.advance(now.Sub(last))
+			last = now
+		}
+	}
+}
+
+// advance moves the playback clock forward by elapsed wall time
+func (p *Player) advance(elapsed time.Duration) {
+	log := logger.GetLogger()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paused {
+		return
+	}
+
+	end := p.frames[len(p.frames)-1].Time
+	p.position = p.position.Add(time.Duration(float64(elapsed) * p.speed))
+	if !p.position.Before(end) {
+		p.position = end
+		p.paused = true
+		log.Info("Replay reached end of recording")
+	}
+
+	if err := p.applyLocked(p.frameAt(p.position)); err != nil {
+		log.WithError(err).Error("Failed to apply replay frame")
+	}
+}
+
+// frameAt returns the index of the last frame at or before t
+func (p *Player) frameAt(t time.Time) int {
+	idx := sort.Search(len(p.frames), func(i int) bool {
+		return p.frames[i].Time.After(t)
+	})
+	if idx == 0 {
+		return 0
+	}
+	return idx - 1
+}
+
+// apply loads a frame into the fake cluster
+func (p *Player) apply(index int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.applyLocked(index)
+}
+
+// applyLocked loads a frame into the fake cluster; the caller holds p.mu
+func (p *Player) applyLocked(index int) error {
+	if index == p.index {
+		return nil
+	}
+
+	frame := p.frames[index]
+	tracker := p.client.Tracker()
+
+	hpas := make([]runtime.Object, len(frame.HPAs))
+	for i := range frame.HPAs {
+		hpas[i] = &frame.HPAs[i]
+	}
+	events := make([]runtime.Object, len(frame.Events))
+	for i := range frame.Events {
+		events[i] = &frame.Events[i]
+	}
+	deployments := make([]runtime.Object, len(frame.Deployments))
+	for i := range frame.Deployments {
+		deployments[i] = &frame.Deployments[i]
+	}
+	statefulSets := make([]runtime.Object, len(frame.StatefulSets))
+	for i := range frame.StatefulSets {
+		statefulSets[i] = &frame.StatefulSets[i]
+	}
+
+	for _, set := range []struct {
+		gvr     schema.GroupVersionResource
+		kind    string
+		objects []runtime.Object
+	}{
+		{hpaResource, "HorizontalPodAutoscaler", hpas},
+		{eventResource, "Event", events},
+		{deploymentResource, "Deployment", deployments},
+		{statefulSetResource, "StatefulSet", statefulSets},
+	} {
+		if err := syncObjects(tracker, set.gvr, set.gvr.GroupVersion().WithKind(set.kind), set.objects); err != nil {
+			return fmt.Errorf("failed to load frame %d: %v", index, err)
+		}
+	}
+
+	p.index = index
+	return nil
+}
+
+// syncObjects makes the tracker contain exactly the given objects for a resource
+func syncObjects(tracker k8stesting.ObjectTracker, gvr schema.GroupVersionResource, gvk schema.GroupVersionKind, objects []runtime.Object) error {
+	keep := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		keep[accessor.GetNamespace()+"/"+accessor.GetName()] = true
+
+		err = tracker.Update(gvr, obj, accessor.GetNamespace())
+		if apierrors.IsNotFound(err) {
+			err = tracker.Create(gvr, obj, accessor.GetNamespace())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	list, err := tracker.List(gvr, gvk, "")
+	if err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		if !keep[accessor.GetNamespace()+"/"+accessor.GetName()] {
+			if err := tracker.Delete(gvr, accessor.GetNamespace(), accessor.GetName()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"hpa-monitor/pkg/logger"
+)
+
+// Recorder periodically writes cluster snapshots to an NDJSON file
+type Recorder struct {
+	client   kubernetes.Interface
+	path     string
+	interval time.Duration
+	file     *os.File
+}
+
+// NewRecorder creates a recorder that appends frames to path every interval
+func NewRecorder(client kubernetes.Interface, path string, interval time.Duration) *Recorder {
+	return &Recorder{
+		client:   client,
+		path:     path,
+		interval: interval,
+	}
+}
+
+// Run records frames until the context is cancelled
+func (r *Recorder) Run(ctx context.Context) error {
+	log := logger.GetLogger()
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open recording file: %v", err)
+	}
+	r.file = file
+	defer file.Close()
+
+	log.WithFields(logger.Fields{
+		"path":     r.path,
+		"interval": r.interval.String(),
+	}).Info("Snapshot recorder started")
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.record(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Snapshot recorder stopped")
+			return nil
+		case <-ticker.C:
+			r.record(ctx)
+		}
+	}
+}
+
+// record captures one frame and appends it to the file
+func (r *Recorder) record(ctx context.Context) {
+	log := logger.GetLogger()
+
+	frame, err := r.capture(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to capture snapshot frame")
+		return
+	}
+
+	data, err := json.Marshal(frame)
+	if err != nil {
+		log.WithError(err).Error("Failed to encode snapshot frame")
+		return
+	}
+
+	if _, err := r.file.Write(append(data, '\n')); err != nil {
+		log.WithError(err).Error("Failed to write snapshot frame")
+		return
+	}
+
+	log.WithFields(logger.Fields{
+		"hpa_count":   len(frame.HPAs),
+		"event_count": len(frame.Events),
+	}).Debug("Snapshot frame recorded")
+}
+
+// capture lists HPAs, their events and their scale targets
+func (r *Recorder) capture(ctx context.Context) (*Frame, error) {
+	log := logger.GetLogger()
+	frame := &Frame{Time: time.Now()}
+
+	hpaList, err := r.client.AutoscalingV2().HorizontalPodAutoscalers("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HPAs: %v", err)
+	}
+	frame.HPAs = hpaList.Items
+
+	events, err := r.client.CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=HorizontalPodAutoscaler",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %v", err)
+	}
+	frame.Events = events.Items
+
+	for _, hpa := range hpaList.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		switch ref.Kind {
+		case "Deployment":
+			deployment, err := r.client.AppsV1().Deployments(hpa.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				log.WithFields(logger.Fields{
+					"namespace": hpa.Namespace,
+					"name":      ref.Name,
+				}).WithError(err).Debug("Failed to get scale target deployment")
+				continue
+			}
+			frame.Deployments = append(frame.Deployments, *deployment)
+		case "StatefulSet":
+			statefulSet, err := r.client.AppsV1().StatefulSets(hpa.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				log.WithFields(logger.Fields{
+					"namespace": hpa.Namespace,
+					"name":      ref.Name,
+				}).WithError(err).Debug("Failed to get scale target statefulset")
+				continue
+			}
+			frame.StatefulSets = append(frame.StatefulSets, *statefulSet)
+		}
+	}
+
+	return frame, nil
+}
@@ -0,0 +1,187 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// hpa returns shop/name scaled to replicas
+func hpa(name string, replicas int32) autoscalingv2.HorizontalPodAutoscaler {
+	return autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MaxReplicas:    10,
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: replicas},
+	}
+}
+
+// listed returns the HPAs in the client as "name=replicas"
+func listed(t *testing.T, client kubernetes.Interface) []string {
+	t.Helper()
+	list, err := client.AutoscalingV2().HorizontalPodAutoscalers("").List(t.Context(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing HPAs: %v", err)
+	}
+	var result []string
+	for _, item := range list.Items {
+		result = append(result, item.Name+"="+strconv.Itoa(int(item.Status.CurrentReplicas)))
+	}
+	slices.Sort(result)
+	return result
+}
+
+// recording has three frames a minute apart: checkout scales up and storefront is
+// replaced by search in the second, and the third is empty
+func recording() []Frame {
+	return []Frame{
+		{Time: start, HPAs: []autoscalingv2.HorizontalPodAutoscaler{hpa("checkout", 1), hpa("storefront", 2)}},
+		{Time: start.Add(time.Minute), HPAs: []autoscalingv2.HorizontalPodAutoscaler{hpa("checkout", 3), hpa("search", 2)}},
+		{Time: start.Add(2 * time.Minute)},
+	}
+}
+
+func TestPlayerSyncsFrames(t *testing.T) {
+	player, err := NewPlayer(recording(), 1)
+	if err != nil {
+		t.Fatalf("creating player: %v", err)
+	}
+	if got := listed(t, player.Client()); !slices.Equal(got, []string{"checkout=1", "storefront=2"}) {
+		t.Errorf("first frame: HPAs %v", got)
+	}
+
+	// Objects are updated, created and deleted to match the frame
+	if err := player.Seek(start.Add(90 * time.Second)); err != nil {
+		t.Fatalf("seeking: %v", err)
+	}
+	if got := listed(t, player.Client()); !slices.Equal(got, []string{"checkout=3", "search=2"}) {
+		t.Errorf("second frame: HPAs %v, want checkout updated, search created and storefront deleted", got)
+	}
+	if status := player.Status(); status.Frame != 1 || !status.Position.Equal(start.Add(90*time.Second)) {
+		t.Errorf("status %+v, want frame 1 at 1m30s", status)
+	}
+
+	if err := player.Seek(start.Add(time.Hour)); err != nil {
+		t.Fatalf("seeking: %v", err)
+	}
+	if got := listed(t, player.Client()); len(got) != 0 {
+		t.Errorf("last frame: HPAs %v, want none", got)
+	}
+	if status := player.Status(); status.Frame != 2 || !status.Position.Equal(status.End) {
+		t.Errorf("status %+v, want a seek past the end clamped to the last frame", status)
+	}
+
+	if err := player.Seek(start.Add(-time.Hour)); err != nil {
+		t.Fatalf("seeking: %v", err)
+	}
+	if status := player.Status(); status.Frame != 0 || !status.Position.Equal(start) {
+		t.Errorf("status %+v, want a seek before the start clamped to the first frame", status)
+	}
+	if got := listed(t, player.Client()); !slices.Equal(got, []string{"checkout=1", "storefront=2"}) {
+		t.Errorf("back at the first frame: HPAs %v", got)
+	}
+}
+
+func TestPlayerAdvance(t *testing.T) {
+	player, err := NewPlayer(recording(), 4)
+	if err != nil {
+		t.Fatalf("creating player: %v", err)
+	}
+	if err := player.SetSpeed(0); err == nil {
+		t.Error("SetSpeed(0) succeeded, want an error")
+	}
+
+	// At 4x, 15s of wall time is a minute of recording
+	player.advance(15 * time.Second)
+	if status := player.Status(); status.Frame != 1 || !status.Position.Equal(start.Add(time.Minute)) {
+		t.Errorf("status %+v, want frame 1 at 1m", status)
+	}
+
+	player.SetPaused(true)
+	player.advance(time.Minute)
+	if status := player.Status(); !status.Position.Equal(start.Add(time.Minute)) {
+		t.Errorf("position %s while paused, want 1m", status.Position.Sub(start))
+	}
+
+	player.SetPaused(false)
+	if err := player.SetSpeed(1); err != nil {
+		t.Fatalf("setting speed: %v", err)
+	}
+	player.advance(30 * time.Second)
+	if status := player.Status(); status.Frame != 1 || !status.Position.Equal(start.Add(90*time.Second)) {
+		t.Errorf("status %+v, want frame 1 at 1m30s", status)
+	}
+
+	// Playback pauses at the end of the recording
+	player.advance(time.Hour)
+	if status := player.Status(); status.Frame != 2 || !status.Paused || !status.Position.Equal(status.End) {
+		t.Errorf("status %+v, want paused on the last frame", status)
+	}
+}
+
+func TestRecorderFramesRoundTrip(t *testing.T) {
+	checkout := hpa("checkout", 3)
+	client := fake.NewSimpleClientset(
+		&checkout,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "shop", Name: "checkout.1"},
+			InvolvedObject: v1.ObjectReference{Kind: "HorizontalPodAutoscaler", Namespace: "shop", Name: "checkout"},
+			Reason:         "SuccessfulRescale",
+		},
+	)
+	path := filepath.Join(t.TempDir(), "recording.ndjson")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("creating recording: %v", err)
+	}
+	recorder := NewRecorder(client, path, time.Minute)
+	recorder.file = file
+	recorder.record(t.Context())
+	recorder.record(t.Context())
+	file.Close()
+
+	frames, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("loading recording: %v", err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("read %d frames, want 2", len(frames))
+	}
+	frame := frames[0]
+	if len(frame.HPAs) != 1 || frame.HPAs[0].Status.CurrentReplicas != 3 {
+		t.Errorf("HPAs %+v, want checkout at 3 replicas", frame.HPAs)
+	}
+	if len(frame.Events) != 1 || frame.Events[0].Reason != "SuccessfulRescale" {
+		t.Errorf("events %+v, want the rescale", frame.Events)
+	}
+	if len(frame.Deployments) != 1 || frame.Deployments[0].Name != "checkout" {
+		t.Errorf("deployments %+v, want the scale target", frame.Deployments)
+	}
+
+	// A recorded frame plays back into the same objects
+	player, err := NewPlayer(frames, 1)
+	if err != nil {
+		t.Fatalf("creating player: %v", err)
+	}
+	if got := listed(t, player.Client()); !slices.Equal(got, []string{"checkout=3"}) {
+		t.Errorf("played back HPAs %v, want checkout=3", got)
+	}
+	if _, err := player.Client().AppsV1().Deployments("shop").Get(t.Context(), "checkout", metav1.GetOptions{}); err != nil {
+		t.Errorf("played back deployment: %v", err)
+	}
+}
@@ -0,0 +1,349 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"hpa-monitor/pkg/api"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+)
+
+// Status filters accepted by ?status= on /api/v1/hpas
+const (
+	StatusFilterAtMax          = "at-max"
+	StatusFilterNotReady       = "not-ready"
+	StatusFilterOutOfTolerance = "out-of-tolerance"
+)
+
+// statusFilters match an HPA for each accepted ?status= value
+var statusFilters = map[string]func(monitor.HPAStatus) bool{
+	StatusFilterAtMax: func(status monitor.HPAStatus) bool {
+		return status.CurrentReplicas >= status.MaxReplicas
+	},
+	StatusFilterNotReady: func(status monitor.HPAStatus) bool {
+		return !status.Ready
+	},
+	StatusFilterOutOfTolerance: func(status monitor.HPAStatus) bool {
+		return status.Ratio != nil && math.Abs(*status.Ratio-1.0) > status.Tolerance
+	},
+}
+
+// sortKeys order HPAs for each accepted ?sort= value; ties fall back to namespace/name
+var sortKeys = map[string]func(a, b monitor.HPAStatus) int{
+	"name": func(a, b monitor.HPAStatus) int {
+		if order := strings.Compare(a.Namespace, b.Namespace); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Name, b.Name)
+	},
+	"replicas": func(a, b monitor.HPAStatus) int {
+		return int(a.CurrentReplicas) - int(b.CurrentReplicas)
+	},
+	"ratio": func(a, b monitor.HPAStatus) int {
+		// HPAs without a ratio sort as the lowest
+		switch {
+		case a.Ratio == nil && b.Ratio == nil:
+			return 0
+		case a.Ratio == nil:
+			return -1
+		case b.Ratio == nil:
+			return 1
+		case *a.Ratio < *b.Ratio:
+			return -1
+		case *a.Ratio > *b.Ratio:
+			return 1
+		}
+		return 0
+	},
+}
+
+// maxListLimit caps the page size of /api/v1/hpas
+const maxListLimit = 500
+
+// hpaListQuery holds the parsed query parameters of /api/v1/hpas
+type hpaListQuery struct {
+	namespaces map[string]bool
+	selector   labels.Selector
+	statuses   []string
+	sortKey    string
+	descending bool
+	limit      int
+	offset     int
+	fields     fieldSelection
+}
+
+// fieldSelection picks the JSON fields returned per HPA: either only the included
+// fields (plus namespace and name), or everything but the excluded ones
+type fieldSelection struct {
+	include map[string]bool
+	exclude map[string]bool
+}
+
+// continueToken is the decoded form of the opaque ?continue= value
+type continueToken struct {
+	Offset int `json:"offset"`
+}
+
+// parseHPAListQuery validates the query parameters of /api/v1/hpas
+func parseHPAListQuery(values url.Values) (*hpaListQuery, error) {
+	query := &hpaListQuery{
+		selector: labels.Everything(),
+		sortKey:  "name",
+	}
+
+	if namespaces := queryList(values, "namespace"); len(namespaces) > 0 {
+		query.namespaces = make(map[string]bool, len(namespaces))
+		for _, namespace := range namespaces {
+			query.namespaces[namespace] = true
+		}
+	}
+	if raw := values.Get("labelSelector"); raw != "" {
+		selector, err := labels.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %v", err)
+		}
+		query.selector = selector
+	}
+	for _, status := range queryList(values, "status") {
+		if _, ok := statusFilters[status]; !ok {
+			return nil, fmt.Errorf("invalid status %q: must be one of %s, %s, %s",
+				status, StatusFilterAtMax, StatusFilterNotReady, StatusFilterOutOfTolerance)
+		}
+		query.statuses = append(query.statuses, status)
+	}
+	if raw := values.Get("sort"); raw != "" {
+		query.sortKey = strings.TrimPrefix(raw, "-")
+		query.descending = strings.HasPrefix(raw, "-")
+		if _, ok := sortKeys[query.sortKey]; !ok {
+			return nil, fmt.Errorf("invalid sort %q: must be name, replicas or ratio, optionally prefixed with -", raw)
+		}
+	}
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return nil, fmt.Errorf("invalid limit %q: must be between 1 and %d", raw, maxListLimit)
+		}
+		query.limit = limit
+	}
+	if raw := values.Get("continue"); raw != "" {
+		var token continueToken
+		data, err := base64.RawURLEncoding.DecodeString(raw)
+		if err == nil {
+			err = json.Unmarshal(data, &token)
+		}
+		if err != nil || token.Offset < 0 {
+			return nil, fmt.Errorf("invalid continue token")
+		}
+		query.offset = token.Offset
+	}
+	for _, field := range queryList(values, "fields") {
+		if strings.HasPrefix(field, "-") {
+			if query.fields.exclude == nil {
+				query.fields.exclude = make(map[string]bool)
+			}
+			query.fields.exclude[strings.TrimPrefix(field, "-")] = true
+		} else {
+			if query.fields.include == nil {
+				query.fields.include = map[string]bool{"namespace": true, "name": true}
+			}
+			query.fields.include[field] = true
+		}
+	}
+	if query.fields.include != nil && query.fields.exclude != nil {
+		return nil, fmt.Errorf("invalid fields: cannot mix included and -excluded fields")
+	}
+
+	return query, nil
+}
+
+// FilterHPAs applies the namespace, labelSelector, status and sort parameters of
+// /api/v1/hpas to statuses, without paging
+func FilterHPAs(statuses []monitor.HPAStatus, values url.Values) ([]monitor.HPAStatus, error) {
+	filters := url.Values{}
+	for _, key := range []string{"namespace", "labelSelector", "status", "sort"} {
+		if value, ok := values[key]; ok {
+			filters[key] = value
+		}
+	}
+	query, err := parseHPAListQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	filtered, _, _ := query.page(statuses)
+	return filtered, nil
+}
+
+// matches reports whether an HPA passes the namespace, label and status filters
+func (q *hpaListQuery) matches(status monitor.HPAStatus) bool {
+	if q.namespaces != nil && !q.namespaces[status.Namespace] {
+		return false
+	}
+	if !q.selector.Matches(labels.Set(status.Labels)) {
+		return false
+	}
+	for _, filter := range q.statuses {
+		if !statusFilters[filter](status) {
+			return false
+		}
+	}
+	return true
+}
+
+// page filters and sorts the HPAs and returns the requested page, the number of
+// matches and the continue token of the next page
+func (q *hpaListQuery) page(statuses []monitor.HPAStatus) ([]monitor.HPAStatus, int, string) {
+	matched := make([]monitor.HPAStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if q.matches(status) {
+			matched = append(matched, status)
+		}
+	}
+
+	compare := sortKeys[q.sortKey]
+	sort.SliceStable(matched, func(i, j int) bool {
+		order := compare(matched[i], matched[j])
+		if q.descending {
+			order = -order
+		}
+		if order != 0 {
+			return order < 0
+		}
+		if matched[i].Namespace != matched[j].Namespace {
+			return matched[i].Namespace < matched[j].Namespace
+		}
+		return matched[i].Name < matched[j].Name
+	})
+
+	start := q.offset
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := len(matched)
+	var next string
+	if q.limit > 0 && start+q.limit < end {
+		end = start + q.limit
+		data, _ := json.Marshal(continueToken{Offset: end})
+		next = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return matched[start:end], len(matched), next
+}
+
+// statusOptions limits the cluster fetch to the requested namespaces, listing events only when withEvents is set
+func (q *hpaListQuery) statusOptions(withEvents bool) monitor.StatusOptions {
+	opts := monitor.StatusOptions{SkipEvents: !withEvents}
+	for namespace := range q.namespaces {
+		opts.Namespaces = append(opts.Namespaces, namespace)
+	}
+	sort.Strings(opts.Namespaces)
+	return opts
+}
+
+// apply filters, sorts and pages the HPAs
+func (q *hpaListQuery) apply(statuses []monitor.HPAStatus) api.HPAList {
+	page, total, next := q.page(statuses)
+	return api.HPAList{Items: page, Total: total, Continue: next}
+}
+
+// includes reports whether the field is returned
+func (f fieldSelection) includes(field string) bool {
+	if f.include != nil {
+		return f.include[field]
+	}
+	return !f.exclude[field]
+}
+
+// project returns the list unchanged when no fields were selected, otherwise its JSON
+// encoding with each item reduced to the selected fields
+func (f fieldSelection) project(list api.HPAList) (interface{}, error) {
+	if f.include == nil && f.exclude == nil {
+		return list, nil
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(body["items"], &items); err != nil {
+		return nil, err
+	}
+	for _, fields := range items {
+		for key := range fields {
+			if (f.include != nil && !f.include[key]) || f.exclude[key] {
+				delete(fields, key)
+			}
+		}
+	}
+	if body["items"], err = json.Marshal(items); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// handleListHPAs returns a filtered, sorted page of HPAs, e.g.
+// /api/v1/hpas?namespace=shop&status=at-max&sort=-ratio&limit=20&fields=-events
+func (s *Server) handleListHPAs(c *gin.Context) {
+	log := logger.GetLogger()
+
+	query, err := parseHPAListQuery(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hpaStatuses, err := s.hpaMonitor.ListHPAStatus(c.Request.Context(), query.statusOptions(query.fields.includes("events")))
+	if err != nil {
+		log.WithError(err).Error("Failed to get HPA status via HTTP API")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	list := query.apply(hpaStatuses)
+	body, err := query.fields.project(list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.WithFields(logger.Fields{
+		"hpa_count": len(list.Items),
+		"total":     list.Total,
+	}).Debug("HPA list request completed")
+	c.JSON(http.StatusOK, body)
+}
+
+// handleGetHPA returns the full status of a single HPA, including conditions and events
+func (s *Server) handleGetHPA(c *gin.Context) {
+	log := logger.GetLogger()
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	status, err := s.hpaMonitor.GetHPAStatusByName(c.Request.Context(), namespace, name)
+	if apierrors.IsNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "HPA " + history.Key(namespace, name) + " not found"})
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Failed to get HPA status via HTTP API")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
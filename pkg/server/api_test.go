@@ -0,0 +1,168 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/leader"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/transition"
+)
+
+func TestHPAListSort(t *testing.T) {
+	statuses := []monitor.HPAStatus{
+		{Namespace: "shop", Name: "checkout", CurrentReplicas: 3},
+		{Namespace: "batch", Name: "report-worker", CurrentReplicas: 5},
+		{Namespace: "shop", Name: "storefront", CurrentReplicas: 3},
+	}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"name", []string{"batch/report-worker", "shop/checkout", "shop/storefront"}},
+		{"-name", []string{"shop/storefront", "shop/checkout", "batch/report-worker"}},
+		{"replicas", []string{"shop/checkout", "shop/storefront", "batch/report-worker"}},
+		{"-replicas", []string{"batch/report-worker", "shop/checkout", "shop/storefront"}},
+	}
+	for _, test := range tests {
+		query, err := parseHPAListQuery(url.Values{"sort": {test.sort}})
+		if err != nil {
+			t.Fatalf("sort=%s: %v", test.sort, err)
+		}
+		page, _, _ := query.page(statuses)
+
+		var got []string
+		for _, status := range page {
+			got = append(got, status.Namespace+"/"+status.Name)
+		}
+		if len(got) != len(test.want) {
+			t.Fatalf("sort=%s: got %v, want %v", test.sort, got, test.want)
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("sort=%s: got %v, want %v", test.sort, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestHPAHandlersFetchOnlyWhatTheyReturn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientset := fake.NewSimpleClientset(
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "report-worker"}},
+	)
+	s := NewServer(monitor.NewHPAMonitor(clientset), nil, nil, config.Default())
+	r := gin.New()
+	s.setupAPIRoutes(r)
+
+	tests := []struct {
+		url    string
+		status int
+		// want lists the expected API calls as "verb resource namespace"
+		want []string
+	}{
+		{"/api/v1/hpas?namespace=shop&fields=-events", http.StatusOK, []string{
+			"list horizontalpodautoscalers shop",
+			"get namespaces ",
+		}},
+		{"/api/v1/hpas?namespace=shop", http.StatusOK, []string{
+			"list horizontalpodautoscalers shop",
+			"get namespaces ",
+			"list events shop",
+		}},
+		{"/api/v1/namespaces/batch/hpas/report-worker", http.StatusOK, []string{
+			"get horizontalpodautoscalers batch",
+			"get namespaces ",
+			"list events batch",
+		}},
+		{"/api/v1/namespaces/batch/hpas/missing", http.StatusNotFound, []string{
+			"get horizontalpodautoscalers batch",
+		}},
+	}
+	for _, test := range tests {
+		clientset.ClearActions()
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.url, nil))
+		if rec.Code != test.status {
+			t.Errorf("%s: status %d, want %d", test.url, rec.Code, test.status)
+		}
+
+		var got []string
+		for _, action := range clientset.Actions() {
+			got = append(got, action.GetVerb()+" "+action.GetResource().Resource+" "+action.GetNamespace())
+		}
+		if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+			t.Errorf("%s: API calls %v, want %v", test.url, got, test.want)
+		}
+	}
+}
+
+func TestLeaderOnlyRoutesOnFollowers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientset := fake.NewSimpleClientset(
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+	)
+	// An elector that has not won the Lease is a follower
+	follower := leader.NewElector(clientset, "default", "hpa-monitor", "replica-b")
+	s := NewServer(monitor.NewHPAMonitor(clientset), history.NewStore(time.Minute, time.Hour), follower, config.Default())
+	s.SetTransitions(transition.NewTracker("", time.Hour))
+	r := gin.New()
+	s.setupAPIRoutes(r)
+
+	tests := []struct {
+		url    string
+		status int
+	}{
+		{"/api/v1/namespaces/shop/hpas/checkout/recommendations", http.StatusServiceUnavailable},
+		{"/api/v1/namespaces/shop/hpas/checkout/conditions", http.StatusServiceUnavailable},
+		{"/api/hpa/shop/checkout/recommendations", http.StatusServiceUnavailable},
+		{"/api/v1/transitions", http.StatusServiceUnavailable},
+		{"/api/v1/export?history=true", http.StatusServiceUnavailable},
+		{"/api/v1/export", http.StatusOK},
+		{"/api/v1/hpas", http.StatusOK},
+	}
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.url, nil))
+		if rec.Code != test.status {
+			t.Errorf("%s on a follower: status %d, want %d", test.url, rec.Code, test.status)
+		}
+	}
+}
+
+func TestSimulateManifestSkipsNamespaceLookup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientset := fake.NewSimpleClientset()
+	s := NewServer(monitor.NewHPAMonitor(clientset), nil, nil, config.Default())
+	r := gin.New()
+	s.setupAPIRoutes(r)
+
+	manifest := `{"apiVersion": "autoscaling/v2", "kind": "HorizontalPodAutoscaler",
+		"metadata": {"namespace": "not-created-yet", "name": "checkout"},
+		"spec": {"maxReplicas": 10, "metrics": [{"type": "Resource", "resource": {"name": "cpu", "target": {"type": "Utilization", "averageUtilization": 50}}}]}}`
+	body, _ := json.Marshal(map[string]interface{}{"manifest": manifest, "series": []map[string]float64{{"offset": 0, "value": 50}}})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/simulate", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if actions := clientset.Actions(); len(actions) != 0 {
+		t.Errorf("API calls %v, want none for a manifest", actions)
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache-Control values for assets: hashed URLs never change content, plain names are revalidated
+const (
+	cacheImmutable  = "public, max-age=31536000, immutable"
+	cacheRevalidate = "no-cache"
+)
+
+// indexPage is the dashboard template; assets are served under assetPathPrefix with
+// assetHashLength hex digits of their SHA-256 in the name
+const (
+	indexPage       = "index.html"
+	assetPathPrefix = "/assets/"
+	assetHashLength = 12
+)
+
+// asset is a static file with its content hash
+type asset struct {
+	name    string
+	content []byte
+	hash    string
+}
+
+// assetSet is the dashboard page and the static files it links to, loaded from the
+// embedded web assets or from --web-dir
+type assetSet struct {
+	fsys fs.FS
+	// live re-reads the files on every request, so UI changes under --web-dir show on reload
+	live bool
+
+	mu     sync.Mutex
+	loaded *loadedAssets
+}
+
+// loadedAssets is one read of the web assets
+type loadedAssets struct {
+	index *template.Template
+	// byName holds each asset under its file name and under its hashed name
+	byName map[string]*asset
+	// urls maps file names to their hashed URLs, for the index template
+	urls map[string]string
+}
+
+// newAssetSet reads the web assets once, so a missing or broken file fails at startup
+func newAssetSet(fsys fs.FS, live bool) (*assetSet, error) {
+	loaded, err := loadAssets(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &assetSet{fsys: fsys, live: live, loaded: loaded}, nil
+}
+
+// get returns the current assets, re-reading them when live
+func (a *assetSet) get() (*loadedAssets, error) {
+	if !a.live {
+		return a.loaded, nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	loaded, err := loadAssets(a.fsys)
+	if err != nil {
+		return nil, err
+	}
+	a.loaded = loaded
+	return loaded, nil
+}
+
+// loadAssets parses index.html and hashes every other top-level file except templates
+func loadAssets(fsys fs.FS) (*loadedAssets, error) {
+	index, err := template.ParseFS(fsys, indexPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard template: %v", err)
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read web assets: %v", err)
+	}
+
+	loaded := &loadedAssets{index: index, byName: make(map[string]*asset), urls: make(map[string]string)}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, ".html") || strings.HasSuffix(name, ".go") {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", name, err)
+		}
+		sum := sha256.Sum256(content)
+		a := &asset{name: name, content: content, hash: hex.EncodeToString(sum[:])[:assetHashLength]}
+		ext := path.Ext(name)
+		hashed := strings.TrimSuffix(name, ext) + "." + a.hash + ext
+		loaded.byName[name] = a
+		loaded.byName[hashed] = a
+		loaded.urls[name] = assetPathPrefix + hashed
+	}
+	return loaded, nil
+}
+
+// handleIndex serves the main dashboard page, linking assets by their hashed URLs and
+// prefixing every URL with the path the browser reached the server under. Its only
+// script runs with a per-response nonce, and it carries the CSRF token for POSTs.
+func (s *Server) handleIndex(c *gin.Context) {
+	loaded, err := s.assets.get()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	basePath := s.publicBasePath(c.Request)
+	nonce := randomString(16)
+	var page bytes.Buffer
+	data := gin.H{
+		"Assets":    loaded.urls,
+		"BasePath":  basePath,
+		"Secure":    isSecure(c.Request),
+		"Nonce":     nonce,
+		"CSRFToken": csrfToken(c, basePath+"/"),
+	}
+	if err := loaded.index.Execute(&page, data); err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	// The script nonce is new for every response, so the page is never cached
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Security-Policy", "default-src 'self'; script-src 'nonce-"+nonce+"'; "+
+		"style-src 'self' 'unsafe-inline'; img-src 'self' data:; connect-src 'self'; "+
+		"object-src 'none'; base-uri 'none'; form-action 'self'; frame-ancestors "+s.frameAncestors())
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// handleAsset serves a static file. Hashed names such as style.1a2b3c4d5e6f.css are
+// cached for a year; plain names, kept for old links, are revalidated by ETag.
+func (s *Server) handleAsset(c *gin.Context) {
+	loaded, err := s.assets.get()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	name := strings.TrimPrefix(c.Param("file"), "/")
+	if name == "" {
+		name = path.Base(c.Request.URL.Path)
+	}
+	a, ok := loaded.byName[name]
+	if !ok {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	cacheControl := cacheRevalidate
+	if name != a.name && !s.assets.live {
+		cacheControl = cacheImmutable
+	}
+	serveContent(c, a.name, a.content, a.hash, cacheControl)
+}
+
+// serveContent writes content with an ETag, answering If-None-Match with 304 Not Modified
+func serveContent(c *gin.Context, name string, content []byte, hash, cacheControl string) {
+	c.Header("Cache-Control", cacheControl)
+	c.Header("ETag", `"`+hash+`"`)
+	http.ServeContent(c.Writer, c.Request, name, time.Time{}, bytes.NewReader(content))
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"hpa-monitor/pkg/export"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+)
+
+// handleExport downloads the current HPA statuses as CSV, NDJSON or XLSX, e.g.
+// /api/v1/export?format=xlsx&namespace=shop&history=true&window=7d. It accepts the
+// filters and sort order of /api/v1/hpas and always exports every matching HPA.
+func (s *Server) handleExport(c *gin.Context) {
+	log := logger.GetLogger()
+
+	format := c.DefaultQuery("format", export.FormatCSV)
+	contentType, err := export.ContentType(format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	withHistory := false
+	if raw := c.Query("history"); raw != "" {
+		if withHistory, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid history %q: must be true or false", raw)})
+			return
+		}
+	}
+	if withHistory && !s.isLeader() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": s.notLeaderMessage()})
+		return
+	}
+	window := s.history.Retention()
+	if raw := c.Query("window"); raw != "" {
+		if window, err = export.ParseWindow(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// Exports have no events column, so events are not fetched
+	hpaStatuses, err := s.hpaMonitor.ListHPAStatus(c.Request.Context(), monitor.StatusOptions{SkipEvents: true})
+	if err != nil {
+		log.WithError(err).Error("Failed to get HPA status for export")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if hpaStatuses, err = FilterHPAs(hpaStatuses, c.Request.URL.Query()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := s.hpaMonitor.Now()
+	var store *history.Store
+	if withHistory {
+		store = s.history
+	}
+	rows := export.NewRows(hpaStatuses, store, now.Add(-window))
+
+	filename := fmt.Sprintf("hpa-export-%s.%s", now.UTC().Format("20060102T150405Z"), format)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	if err := export.Write(c.Writer, format, rows, withHistory); err != nil {
+		log.WithError(err).Error("Failed to write export")
+		return
+	}
+
+	log.WithFields(logger.Fields{
+		"format":    format,
+		"hpa_count": len(rows),
+		"history":   withHistory,
+	}).Debug("Export completed")
+}
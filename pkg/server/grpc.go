@@ -0,0 +1,399 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+	hpamonitorv1 "hpa-monitor/pkg/pb/hpamonitor/v1"
+	"hpa-monitor/pkg/transition"
+)
+
+// grpcService serves the HTTP API's HPA statuses over gRPC, reusing its list
+// query, subscription filters and broadcast hub
+type grpcService struct {
+	hpamonitorv1.UnimplementedHPAMonitorServiceServer
+	server *Server
+}
+
+// StartGRPC serves the gRPC API, health checking and reflection on the configured
+// gRPC port until ctx is cancelled, then drains streams for up to the shutdown timeout
+func (s *Server) StartGRPC(ctx context.Context) error {
+	log := logger.GetLogger()
+	cfg := s.getConfig()
+
+	listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return err
+	}
+
+	var options []grpc.ServerOption
+	if s.certs != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(s.certs.TLSConfig("h2"))))
+	}
+	grpcServer := grpc.NewServer(options...)
+	hpamonitorv1.RegisterHPAMonitorServiceServer(grpcServer, &grpcService{server: s})
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(hpamonitorv1.HPAMonitorService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	log.WithField("port", cfg.GRPCPort).Info("Starting gRPC server")
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Report NOT_SERVING so load balancers stop routing before streams are drained
+	healthServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	timeout := time.Duration(s.getConfig().ShutdownTimeout) * time.Second
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		grpcServer.Stop()
+		return errors.New("timed out waiting for gRPC streams to close")
+	}
+	log.Info("gRPC server stopped")
+	return nil
+}
+
+// ListHPAs returns a filtered, sorted page of HPAs, like GET /api/v1/hpas
+func (g *grpcService) ListHPAs(ctx context.Context, req *hpamonitorv1.ListHPAsRequest) (*hpamonitorv1.ListHPAsResponse, error) {
+	values := url.Values{}
+	values["namespace"] = req.GetNamespaces()
+	values["status"] = req.GetStatus()
+	if req.GetLabelSelector() != "" {
+		values.Set("labelSelector", req.GetLabelSelector())
+	}
+	if req.GetSort() != "" {
+		values.Set("sort", req.GetSort())
+	}
+	if req.GetPageSize() != 0 {
+		values.Set("limit", strconv.Itoa(int(req.GetPageSize())))
+	}
+	if req.GetPageToken() != "" {
+		values.Set("continue", req.GetPageToken())
+	}
+	query, err := parseHPAListQuery(values)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	hpaStatuses, err := g.server.hpaMonitor.ListHPAStatus(ctx, query.statusOptions(req.GetIncludeEvents()))
+	if err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to get HPA status via gRPC API")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	page, total, next := query.page(hpaStatuses)
+	response := &hpamonitorv1.ListHPAsResponse{
+		Items:         make([]*hpamonitorv1.HPAStatus, 0, len(page)),
+		Total:         int32(total),
+		NextPageToken: next,
+	}
+	for _, hpaStatus := range page {
+		response.Items = append(response.Items, hpaStatusToProto(hpaStatus))
+	}
+	return response, nil
+}
+
+// GetHPA returns the full status of one HPA, including conditions and events
+func (g *grpcService) GetHPA(ctx context.Context, req *hpamonitorv1.GetHPARequest) (*hpamonitorv1.GetHPAResponse, error) {
+	hpaStatus, err := g.server.hpaMonitor.GetHPAStatusByName(ctx, req.GetNamespace(), req.GetName())
+	if apierrors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "HPA %s not found", history.Key(req.GetNamespace(), req.GetName()))
+	}
+	if err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to get HPA status via gRPC API")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &hpamonitorv1.GetHPAResponse{Hpa: hpaStatusToProto(hpaStatus)}, nil
+}
+
+// WatchHPAs sends a snapshot of the matching HPAs, then a patch every interval and
+// scale transitions as they happen, until the client cancels or the server stops
+func (g *grpcService) WatchHPAs(req *hpamonitorv1.WatchHPAsRequest, stream grpc.ServerStreamingServer[hpamonitorv1.WatchHPAsResponse]) error {
+	s := g.server
+	log := logger.GetLogger()
+	clientIP := "unknown"
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		clientIP = p.Addr.String()
+	}
+
+	cfg := s.getConfig()
+	includeEvents := req.GetIncludeEvents()
+	sub, err := newSubscription(ClientMessage{
+		Type:            messageTypeSubscribe,
+		Namespaces:      req.GetNamespaces(),
+		LabelSelector:   req.GetLabelSelector(),
+		HPAs:            req.GetHpas(),
+		IntervalSeconds: int(req.GetIntervalSeconds()),
+		IncludeEvents:   &includeEvents,
+	},
+		time.Duration(cfg.WebSocketInterval)*time.Second,
+		time.Duration(cfg.WebSocketMinInterval)*time.Second,
+		time.Duration(cfg.WebSocketMaxInterval)*time.Second)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.WithField("client_ip", clientIP).Info("gRPC watch established")
+	ctx := stream.Context()
+	messages := s.register()
+	defer s.unregister(messages)
+
+	visible := make(map[string]bool)
+	deltas := newDeltaStream()
+	hpaStatuses, err := s.hpaMonitor.ListHPAStatus(ctx, sub.statusOptions())
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.WithField("client_ip", clientIP).WithError(err).Error("Error getting HPA status for gRPC watch snapshot")
+	}
+	hpaStatuses = sub.apply(hpaStatuses)
+	setVisible(visible, hpaStatuses)
+	if err := stream.Send(snapshotToProto(deltas.snapshot(hpaStatuses))); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(sub.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.WithField("client_ip", clientIP).Info("gRPC watch closed")
+			return nil
+		case <-s.shutdown:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case message := <-messages:
+			s.applyReload(message, sub, ticker)
+			// Config notices are dashboard-only; only transitions are part of the watch
+			if _, ok := message.(TransitionsMessage); !ok {
+				continue
+			}
+			filtered, ok := filterBroadcast(message, visible)
+			if !ok {
+				continue
+			}
+			if err := stream.Send(transitionsToProto(filtered.(TransitionsMessage))); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			hpaStatuses, err := s.hpaMonitor.ListHPAStatus(ctx, sub.statusOptions())
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				log.WithField("client_ip", clientIP).WithError(err).Error("Error getting HPA status for gRPC watch")
+				continue
+			}
+			hpaStatuses = sub.apply(hpaStatuses)
+			setVisible(visible, hpaStatuses)
+			if err := stream.Send(patchToProto(deltas.patch(hpaStatuses))); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ListAlerts returns the HPAs whose derived status is not healthy
+func (g *grpcService) ListAlerts(ctx context.Context, req *hpamonitorv1.ListAlertsRequest) (*hpamonitorv1.ListAlertsResponse, error) {
+	hpaStatuses, err := g.server.hpaMonitor.GetHPAStatus(ctx)
+	if err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to get HPA status via gRPC API")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	namespaces := make(map[string]bool, len(req.GetNamespaces()))
+	for _, namespace := range req.GetNamespaces() {
+		namespaces[namespace] = true
+	}
+
+	response := &hpamonitorv1.ListAlertsResponse{}
+	for _, alert := range monitor.Alerts(hpaStatuses, g.server.hpaMonitor.Now()) {
+		if len(namespaces) > 0 && !namespaces[alert.Namespace] {
+			continue
+		}
+		if req.GetFiringOnly() && !alert.Firing {
+			continue
+		}
+		response.Alerts = append(response.Alerts, alertToProto(alert))
+	}
+	return response, nil
+}
+
+// hpaStatusToProto converts an HPA status to its protobuf message
+func hpaStatusToProto(s monitor.HPAStatus) *hpamonitorv1.HPAStatus {
+	message := &hpamonitorv1.HPAStatus{
+		Name:                     s.Name,
+		Namespace:                s.Namespace,
+		Labels:                   s.Labels,
+		MinReplicas:              s.MinReplicas,
+		MaxReplicas:              s.MaxReplicas,
+		CurrentReplicas:          s.CurrentReplicas,
+		DesiredReplicas:          s.DesiredReplicas,
+		CurrentCpuUtilization:    s.CurrentCPUUtilization,
+		TargetCpuUtilization:     s.TargetCPUUtilization,
+		PrimaryMetricName:        s.PrimaryMetricName,
+		PrimaryMetricCurrent:     s.PrimaryMetricCurrent,
+		PrimaryMetricTarget:      s.PrimaryMetricTarget,
+		Ratio:                    s.Ratio,
+		Tolerance:                s.Tolerance,
+		ToleranceAdjustedMin:     s.ToleranceAdjustedMin,
+		ToleranceAdjustedMax:     s.ToleranceAdjustedMax,
+		ToleranceSource:          s.ToleranceSource,
+		ScaleUpTolerance:         s.ScaleUpTolerance,
+		ScaleUpToleranceSource:   s.ScaleUpToleranceSource,
+		ScaleDownTolerance:       s.ScaleDownTolerance,
+		ScaleDownToleranceSource: s.ScaleDownToleranceSource,
+		Owner:                    s.Owner,
+		Runbook:                  s.Runbook,
+		AlertMaxDuration:         s.AlertMaxDuration,
+		AnnotationErrors:         s.AnnotationErrors,
+		LastScaleTime:            s.LastScaleTime,
+		Ready:                    s.Ready,
+		DerivedStatus:            s.DerivedStatus,
+		ScaleUpStabilized:        s.ScaleUpStabilized,
+		ScaleDownStabilized:      s.ScaleDownStabilized,
+	}
+	for _, c := range s.Conditions {
+		message.Conditions = append(message.Conditions, &hpamonitorv1.Condition{
+			Type:               c.Type,
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		})
+	}
+	for _, e := range s.Events {
+		message.Events = append(message.Events, &hpamonitorv1.Event{
+			Type:           e.Type,
+			Reason:         e.Reason,
+			Message:        e.Message,
+			FirstTimestamp: e.FirstTimestamp,
+			LastTimestamp:  e.LastTimestamp,
+			Count:          e.Count,
+			Category:       e.Category,
+			Hint:           e.Hint,
+			RunbookUrl:     e.RunbookURL,
+			OldReplicas:    e.OldReplicas,
+			NewReplicas:    e.NewReplicas,
+			TriggerMetric:  e.TriggerMetric,
+		})
+	}
+	return message
+}
+
+// hpaStatusesToProto converts a list of HPA statuses
+func hpaStatusesToProto(statuses []monitor.HPAStatus) []*hpamonitorv1.HPAStatus {
+	messages := make([]*hpamonitorv1.HPAStatus, 0, len(statuses))
+	for _, s := range statuses {
+		messages = append(messages, hpaStatusToProto(s))
+	}
+	return messages
+}
+
+// snapshotToProto converts a v2 snapshot message to a watch response
+func snapshotToProto(snapshot SnapshotMessage) *hpamonitorv1.WatchHPAsResponse {
+	return &hpamonitorv1.WatchHPAsResponse{
+		Seq: snapshot.Seq,
+		Update: &hpamonitorv1.WatchHPAsResponse_Snapshot{
+			Snapshot: &hpamonitorv1.Snapshot{Hpas: hpaStatusesToProto(snapshot.HPAs)},
+		},
+	}
+}
+
+// patchToProto converts a v2 patch message to a watch response
+func patchToProto(patch PatchMessage) *hpamonitorv1.WatchHPAsResponse {
+	return &hpamonitorv1.WatchHPAsResponse{
+		Seq: patch.Seq,
+		Update: &hpamonitorv1.WatchHPAsResponse_Patch{
+			Patch: &hpamonitorv1.Patch{
+				Added:   hpaStatusesToProto(patch.Added),
+				Updated: hpaStatusesToProto(patch.Updated),
+				Deleted: patch.Deleted,
+			},
+		},
+	}
+}
+
+// transitionsToProto converts broadcast scale transitions to a watch response
+func transitionsToProto(message TransitionsMessage) *hpamonitorv1.WatchHPAsResponse {
+	transitions := make([]*hpamonitorv1.ScaleTransition, 0, len(message.Transitions))
+	for _, t := range message.Transitions {
+		transitions = append(transitions, transitionToProto(t))
+	}
+	return &hpamonitorv1.WatchHPAsResponse{
+		Update: &hpamonitorv1.WatchHPAsResponse_Transitions{
+			Transitions: &hpamonitorv1.Transitions{Transitions: transitions},
+		},
+	}
+}
+
+// transitionToProto converts a scale transition to its protobuf message
+func transitionToProto(t transition.ScaleTransition) *hpamonitorv1.ScaleTransition {
+	return &hpamonitorv1.ScaleTransition{
+		Cluster:       t.Cluster,
+		Namespace:     t.Namespace,
+		Name:          t.Name,
+		Field:         t.Field,
+		From:          t.From,
+		To:            t.To,
+		Direction:     t.Direction,
+		TriggerMetric: t.TriggerMetric,
+		TriggerValue:  t.TriggerValue,
+		Timestamp:     timestamppb.New(t.Timestamp),
+		Source:        t.Source,
+	}
+}
+
+// alertToProto converts an alert to its protobuf message
+func alertToProto(a monitor.Alert) *hpamonitorv1.Alert {
+	message := &hpamonitorv1.Alert{
+		Namespace:     a.Namespace,
+		Name:          a.Name,
+		DerivedStatus: a.DerivedStatus,
+		ConditionType: a.ConditionType,
+		Reason:        a.Reason,
+		Message:       a.Message,
+		MaxDuration:   a.MaxDuration,
+		Firing:        a.Firing,
+		Owner:         a.Owner,
+		Runbook:       a.Runbook,
+	}
+	if a.Since != nil {
+		message.Since = timestamppb.New(*a.Since)
+	}
+	return message
+}
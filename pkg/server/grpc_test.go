@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"hpa-monitor/pkg/api"
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/monitor"
+	hpamonitorv1 "hpa-monitor/pkg/pb/hpamonitor/v1"
+)
+
+// newGRPCTestClient serves the gRPC API of s over an in-memory connection
+func newGRPCTestClient(t *testing.T, s *Server) hpamonitorv1.HPAMonitorServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	hpamonitorv1.RegisterHPAMonitorServiceServer(grpcServer, &grpcService{server: s})
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dialing gRPC: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return hpamonitorv1.NewHPAMonitorServiceClient(conn)
+}
+
+// names returns the namespace/name of each HPA
+func names(hpas []*hpamonitorv1.HPAStatus) []string {
+	var result []string
+	for _, hpa := range hpas {
+		result = append(result, hpa.GetNamespace()+"/"+hpa.GetName())
+	}
+	return result
+}
+
+func TestGRPCService(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientset := fake.NewSimpleClientset(
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "storefront"}},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "report-worker"}},
+	)
+	s := NewServer(monitor.NewHPAMonitor(clientset), nil, nil, config.Default())
+	r := gin.New()
+	s.setupAPIRoutes(r)
+	client := newGRPCTestClient(t, s)
+	ctx := t.Context()
+
+	t.Run("ListHPAs", func(t *testing.T) {
+		first, err := client.ListHPAs(ctx, &hpamonitorv1.ListHPAsRequest{Namespaces: []string{"shop"}, Sort: "-name", PageSize: 1})
+		if err != nil {
+			t.Fatalf("listing HPAs: %v", err)
+		}
+		if got := names(first.GetItems()); len(got) != 1 || got[0] != "shop/storefront" || first.GetTotal() != 2 {
+			t.Errorf("first page %v of %d, want shop/storefront of 2", got, first.GetTotal())
+		}
+
+		// The page token is the REST continue token for the same query
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/hpas?namespace=shop&sort=-name&limit=1", nil))
+		var list api.HPAList
+		if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+			t.Fatalf("decoding REST list: %v", err)
+		}
+		if list.Continue == "" || list.Continue != first.GetNextPageToken() {
+			t.Errorf("REST continue %q, gRPC page token %q, want the same token", list.Continue, first.GetNextPageToken())
+		}
+
+		second, err := client.ListHPAs(ctx, &hpamonitorv1.ListHPAsRequest{Namespaces: []string{"shop"}, Sort: "-name", PageSize: 1, PageToken: list.Continue})
+		if err != nil {
+			t.Fatalf("listing the second page: %v", err)
+		}
+		if got := names(second.GetItems()); len(got) != 1 || got[0] != "shop/checkout" || second.GetNextPageToken() != "" {
+			t.Errorf("second page %v with token %q, want the last page with shop/checkout", got, second.GetNextPageToken())
+		}
+
+		_, err = client.ListHPAs(ctx, &hpamonitorv1.ListHPAsRequest{Sort: "size"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("invalid sort: %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("GetHPA", func(t *testing.T) {
+		response, err := client.GetHPA(ctx, &hpamonitorv1.GetHPARequest{Namespace: "batch", Name: "report-worker"})
+		if err != nil {
+			t.Fatalf("getting HPA: %v", err)
+		}
+		if response.GetHpa().GetName() != "report-worker" {
+			t.Errorf("HPA %v, want batch/report-worker", response.GetHpa())
+		}
+
+		_, err = client.GetHPA(ctx, &hpamonitorv1.GetHPARequest{Namespace: "batch", Name: "missing"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("missing HPA: %v, want NotFound", err)
+		}
+	})
+
+	t.Run("WatchHPAs", func(t *testing.T) {
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := client.WatchHPAs(watchCtx, &hpamonitorv1.WatchHPAsRequest{Namespaces: []string{"batch"}})
+		if err != nil {
+			t.Fatalf("watching HPAs: %v", err)
+		}
+		message, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("receiving: %v", err)
+		}
+		snapshot := message.GetSnapshot()
+		if snapshot == nil || message.GetSeq() != 1 {
+			t.Fatalf("first message %v, want a snapshot with seq 1", message)
+		}
+		if got := names(snapshot.GetHpas()); len(got) != 1 || got[0] != "batch/report-worker" {
+			t.Errorf("snapshot %v, want only batch/report-worker", got)
+		}
+	})
+}
@@ -0,0 +1,362 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"hpa-monitor/pkg/api"
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/export"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/leader"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/openapi"
+	"hpa-monitor/pkg/recommend"
+	"hpa-monitor/pkg/replay"
+	"hpa-monitor/pkg/simulate"
+)
+
+// undocumentedRoutes are registered routes that are not part of the API
+var undocumentedRoutes = map[string]bool{
+	"GET /":              true,
+	"GET /style.css":     true,
+	"HEAD /style.css":    true,
+	"GET /assets/:file":  true,
+	"HEAD /assets/:file": true,
+}
+
+// ginParamPattern matches gin path parameters such as :namespace
+var ginParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// OpenAPIDocument describes every API route and the WebSocket and event stream messages
+func OpenAPIDocument() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:   "HPA Monitor API",
+		Version: Version,
+		Description: "Every /api/v1/<path> is also served at /api/<path> for existing clients. " +
+			"/api/v1/transitions and /api/v1/replay are only served when transition tracking and replay are enabled. " +
+			"With leader election only the leader records history and transitions; followers answer recommendations, " +
+			"condition history, transitions and history exports with 503 and send no transitions on /ws and the stream.",
+	})
+
+	doc.Name(leader.Status{}, "LeaderStatus")
+	doc.Name(replay.Status{}, "ReplayStatus")
+	doc.Name(recommend.Report{}, "RecommendationReport")
+	doc.Name(simulate.Result{}, "SimulationResult")
+	doc.Name(simulate.Step{}, "SimulationStep")
+	doc.Name(simulate.Point{}, "SeriesPoint")
+	doc.Name(config.Change{}, "ConfigChange")
+	doc.Name(export.Row{}, "ExportRow")
+	doc.Name(history.Summary{}, "HistorySummary")
+
+	errorResponse := func(description string) openapi.Response {
+		return openapi.Response{Description: description, Content: doc.JSON(api.Error{})}
+	}
+	ok := func(value interface{}) openapi.Response {
+		return openapi.Response{Description: "OK", Content: doc.JSON(value)}
+	}
+	query := func(name, description string, schema openapi.Schema) openapi.Parameter {
+		return openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+	}
+	stringList := openapi.Schema{"type": "array", "items": openapi.Schema{"type": "string"}}
+	statusList := openapi.Schema{"type": "array", "items": openapi.Schema{
+		"type": "string",
+		"enum": []string{StatusFilterAtMax, StatusFilterNotReady, StatusFilterOutOfTolerance},
+	}}
+	sortKey := openapi.Schema{
+		"type": "string",
+		"enum": []string{"name", "-name", "replicas", "-replicas", "ratio", "-ratio"},
+	}
+	hpaParams := []openapi.Parameter{
+		{Name: "namespace", In: "path", Required: true, Schema: openapi.Schema{"type": "string"}},
+		{Name: "name", In: "path", Required: true, Schema: openapi.Schema{"type": "string"}},
+	}
+
+	doc.Add(http.MethodGet, "/api/v1/hpas", &openapi.Operation{
+		OperationID: "listHPAs",
+		Summary:     "List HPAs, filtered, sorted and paged",
+		Tags:        []string{"hpas"},
+		Parameters: []openapi.Parameter{
+			query("namespace", "Namespaces, repeated or comma-separated", stringList),
+			query("labelSelector", "Kubernetes label selector", openapi.Schema{"type": "string"}),
+			query("status", "Status filters that must all match", statusList),
+			query("sort", "Sort key, prefixed with - for descending", sortKey),
+			query("limit", "Page size", openapi.Schema{"type": "integer", "minimum": 1, "maximum": maxListLimit}),
+			query("continue", "Continue token of the previous page", openapi.Schema{"type": "string"}),
+			query("fields", "JSON fields to return, or to drop when prefixed with -", stringList),
+		},
+		Responses: map[string]openapi.Response{
+			"200": ok(api.HPAList{}),
+			"400": errorResponse("Invalid query parameter"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/namespaces/{namespace}/hpas/{name}", &openapi.Operation{
+		OperationID: "getHPA",
+		Summary:     "Get the full status of one HPA",
+		Tags:        []string{"hpas"},
+		Parameters:  hpaParams,
+		Responses: map[string]openapi.Response{
+			"200": ok(monitor.HPAStatus{}),
+			"404": errorResponse("HPA not found"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/namespaces/{namespace}/hpas/{name}/recommendations", &openapi.Operation{
+		OperationID: "getRecommendations",
+		Summary:     "Get tuning recommendations computed from recorded history",
+		Tags:        []string{"hpas"},
+		Parameters:  hpaParams,
+		Responses: map[string]openapi.Response{
+			"200": ok(recommend.Report{}),
+			"404": errorResponse("No history recorded for the HPA"),
+			"503": errorResponse("Not the leader, which alone records history"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/namespaces/{namespace}/hpas/{name}/conditions", &openapi.Operation{
+		OperationID: "getConditionHistory",
+		Summary:     "Get the condition transitions recorded for one HPA",
+		Tags:        []string{"hpas"},
+		Parameters:  hpaParams,
+		Responses: map[string]openapi.Response{
+			"200": ok(api.ConditionHistory{}),
+			"404": errorResponse("No history recorded for the HPA"),
+			"503": errorResponse("Not the leader, which alone records history"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/transitions", &openapi.Operation{
+		OperationID: "listTransitions",
+		Summary:     "List scale transitions in the order they were observed",
+		Tags:        []string{"events"},
+		Parameters: []openapi.Parameter{
+			query("since", "Observed at or after an RFC3339 timestamp or a duration ago such as 1h", openapi.Schema{"type": "string"}),
+		},
+		Responses: map[string]openapi.Response{
+			"200": ok(api.TransitionList{}),
+			"400": errorResponse("Invalid since"),
+			"503": errorResponse("Not the leader, which alone records transitions"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/events/summary", &openapi.Operation{
+		OperationID: "getEventSummary",
+		Summary:     "Count HPA events per category across the cluster",
+		Tags:        []string{"events"},
+		Responses: map[string]openapi.Response{
+			"200": ok(api.EventSummary{}),
+			"500": errorResponse("Listing HPAs failed"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/export", &openapi.Operation{
+		OperationID: "export",
+		Summary:     "Download every matching HPA as CSV, NDJSON or XLSX",
+		Description: "CSV and XLSX have one column per ExportRow field, with the history fields flattened after them " +
+			"when history is requested. NDJSON has one ExportRow per line.",
+		Tags: []string{"hpas"},
+		Parameters: []openapi.Parameter{
+			query("format", "Export format", openapi.Schema{
+				"type":    "string",
+				"enum":    []string{export.FormatCSV, export.FormatNDJSON, export.FormatXLSX},
+				"default": export.FormatCSV,
+			}),
+			query("history", "Add a summary of each HPA's recorded history", openapi.Schema{"type": "boolean"}),
+			query("window", "History window such as 24h or 7d, default the full retention", openapi.Schema{"type": "string"}),
+			query("namespace", "Namespaces, repeated or comma-separated", stringList),
+			query("labelSelector", "Kubernetes label selector", openapi.Schema{"type": "string"}),
+			query("status", "Status filters that must all match", statusList),
+			query("sort", "Sort key, prefixed with - for descending", sortKey),
+		},
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "Export file, sent as an attachment",
+				Content: map[string]openapi.MediaType{
+					"text/csv":             {Schema: openapi.Schema{"type": "string"}},
+					"application/x-ndjson": {Schema: doc.SchemaOf(export.Row{})},
+					"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {
+						Schema: openapi.Schema{"type": "string", "format": "binary"},
+					},
+				},
+			},
+			"400": errorResponse("Invalid format, window or filter"),
+			"500": errorResponse("Listing HPAs failed"),
+			"503": errorResponse("History requested from a replica that is not the leader"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/snapshot.html", &openapi.Operation{
+		OperationID: "snapshot",
+		Summary:     "Render the dashboard as a self-contained HTML file",
+		Description: "The page embeds its stylesheet, the HPA statuses and a replica sparkline per HPA, so it can be viewed offline. Followers render it without sparklines.",
+		Tags:        []string{"hpas"},
+		Parameters: []openapi.Parameter{
+			query("window", "History drawn in sparklines, such as 6h or 7d", openapi.Schema{"type": "string", "default": "24h"}),
+			query("namespace", "Namespaces, repeated or comma-separated", stringList),
+			query("labelSelector", "Kubernetes label selector", openapi.Schema{"type": "string"}),
+			query("status", "Status filters that must all match", statusList),
+			query("sort", "Sort key, prefixed with - for descending", sortKey),
+		},
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "HTML page",
+				Content:     map[string]openapi.MediaType{"text/html": {Schema: openapi.Schema{"type": "string"}}},
+			},
+			"400": errorResponse("Invalid window or filter"),
+			"500": errorResponse("Listing HPAs or rendering failed"),
+		},
+	})
+	doc.Add(http.MethodPost, "/api/v1/simulate", &openapi.Operation{
+		OperationID: "simulate",
+		Summary:     "Replay the HPA controller algorithm over a metric series",
+		Tags:        []string{"simulate"},
+		RequestBody: &openapi.RequestBody{Required: true, Content: doc.JSON(api.SimulateRequest{})},
+		Responses: map[string]openapi.Response{
+			"200": ok(simulate.Result{}),
+			"400": errorResponse("Invalid request or manifest"),
+			"403": errorResponse("Cross-origin request, or browser request without a valid X-CSRF-Token"),
+			"404": errorResponse("HPA not found"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/config", &openapi.Operation{
+		OperationID: "getConfig",
+		Summary:     "Get the configuration used by the dashboard",
+		Tags:        []string{"server"},
+		Responses:   map[string]openapi.Response{"200": ok(api.ConfigInfo{})},
+	})
+	doc.Add(http.MethodGet, "/api/v1/version", &openapi.Operation{
+		OperationID: "getVersion",
+		Summary:     "Get the build version and leader election state",
+		Tags:        []string{"server"},
+		Responses:   map[string]openapi.Response{"200": ok(api.VersionInfo{})},
+	})
+	doc.Add(http.MethodGet, "/api/v1/replay", &openapi.Operation{
+		OperationID: "getReplayStatus",
+		Summary:     "Get the replay playback position",
+		Tags:        []string{"replay"},
+		Responses:   map[string]openapi.Response{"200": ok(replay.Status{})},
+	})
+	doc.Add(http.MethodPost, "/api/v1/replay", &openapi.Operation{
+		OperationID: "controlReplay",
+		Summary:     "Change replay speed, pause or resume, and seek",
+		Tags:        []string{"replay"},
+		RequestBody: &openapi.RequestBody{Required: true, Content: doc.JSON(api.ReplayControl{})},
+		Responses: map[string]openapi.Response{
+			"200": ok(replay.Status{}),
+			"400": errorResponse("Invalid speed or seek"),
+			"403": errorResponse("Cross-origin request, or browser request without a valid X-CSRF-Token"),
+		},
+	})
+	doc.Add(http.MethodGet, "/api/v1/openapi.json", &openapi.Operation{
+		OperationID: "getOpenAPI",
+		Summary:     "Get this document",
+		Tags:        []string{"server"},
+		Responses: map[string]openapi.Response{"200": {
+			Description: "OK",
+			Content:     map[string]openapi.MediaType{"application/json": {Schema: openapi.Schema{"type": "object"}}},
+		}},
+	})
+
+	serverMessages := []openapi.Schema{
+		doc.SchemaOf(SnapshotMessage{}),
+		doc.SchemaOf(PatchMessage{}),
+		doc.SchemaOf(SubscribedMessage{}),
+		doc.SchemaOf(TransitionsMessage{}),
+		doc.SchemaOf(ConfigReloadedMessage{}),
+	}
+	doc.Add(http.MethodGet, "/api/v1/stream", &openapi.Operation{
+		OperationID: "stream",
+		Summary:     "Receive the WebSocket feed as Server-Sent Events",
+		Description: "Each event's data is one of the x-messages server messages. The subscription and transitions events carry " +
+			"the ID of the latest observed transition; reconnecting with Last-Event-ID replays the transitions observed after it.",
+		Tags: []string{"stream"},
+		Parameters: []openapi.Parameter{
+			query("namespace", "Namespaces, repeated or comma-separated", stringList),
+			query("selector", "Kubernetes label selector", openapi.Schema{"type": "string"}),
+			query("hpa", "HPAs as namespace/name, repeated or comma-separated", stringList),
+			query("interval", "Update interval in seconds", openapi.Schema{"type": "integer"}),
+			query("events", "Include events in HPA statuses", openapi.Schema{"type": "boolean"}),
+			{Name: "Last-Event-ID", In: "header", Schema: openapi.Schema{"type": "string"}},
+		},
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "Event stream",
+				Content:     map[string]openapi.MediaType{"text/event-stream": {Schema: openapi.Schema{"type": "string"}}},
+			},
+			"400": errorResponse("Invalid subscription"),
+		},
+		Messages: &openapi.Messages{Server: serverMessages},
+	})
+	doc.Add(http.MethodGet, "/ws", &openapi.Operation{
+		OperationID: "websocket",
+		Summary:     "Receive HPA updates over a WebSocket",
+		Description: "Without a subprotocol the server sends the []HPAStatus array every interval, and only answers " +
+			"subscribe messages otherwise. With the " + ProtocolV2 + " subprotocol it sends a snapshot followed by patches, " +
+			"transitions and configuration reloads.",
+		Tags: []string{"stream"},
+		Responses: map[string]openapi.Response{
+			"101": {Description: "Switching to the WebSocket protocol"},
+		},
+		Messages: &openapi.Messages{
+			Server: append([]openapi.Schema{
+				doc.SchemaOf([]monitor.HPAStatus{}),
+				doc.SchemaOf(ErrorMessage{}),
+			}, serverMessages...),
+			Client: []openapi.Schema{doc.SchemaOf(ClientMessage{})},
+		},
+	})
+	doc.Add(http.MethodGet, "/health", &openapi.Operation{
+		OperationID: "health",
+		Summary:     "Health check",
+		Tags:        []string{"server"},
+		Responses:   map[string]openapi.Response{"200": ok(api.Health{})},
+	})
+
+	legacy := func(path, operationID, replacement string, response interface{}) {
+		doc.Add(http.MethodGet, path, &openapi.Operation{
+			OperationID: operationID,
+			Summary:     "Deprecated alias of " + replacement,
+			Tags:        []string{"hpas"},
+			Deprecated:  true,
+			Parameters:  parametersOf(path, hpaParams),
+			Responses:   map[string]openapi.Response{"200": ok(response)},
+		})
+	}
+	legacy("/api/hpa", "listHPAsLegacy", "/api/v1/hpas, returning every HPA as a plain array", []monitor.HPAStatus{})
+	legacy("/api/hpa/{namespace}/{name}/recommendations", "getRecommendationsLegacy",
+		"/api/v1/namespaces/{namespace}/hpas/{name}/recommendations", recommend.Report{})
+	legacy("/api/hpa/{namespace}/{name}/conditions", "getConditionHistoryLegacy",
+		"/api/v1/namespaces/{namespace}/hpas/{name}/conditions", api.ConditionHistory{})
+
+	return doc
+}
+
+// parametersOf returns the path parameters that appear in path
+func parametersOf(path string, params []openapi.Parameter) []openapi.Parameter {
+	var result []openapi.Parameter
+	for _, param := range params {
+		if strings.Contains(path, "{"+param.Name+"}") {
+			result = append(result, param)
+		}
+	}
+	return result
+}
+
+// UndocumentedRoutes returns the routes of the engine missing from the OpenAPI document,
+// as "METHOD path". Routes under /api/ are covered by their /api/v1/ counterpart.
+func UndocumentedRoutes(doc *openapi.Document, routes gin.RoutesInfo) []string {
+	var missing []string
+	for _, route := range routes {
+		path := ginParamPattern.ReplaceAllString(route.Path, "{$1}")
+		if undocumentedRoutes[route.Method+" "+route.Path] || doc.Has(route.Method, path) {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(path, "/api/"); ok && doc.Has(route.Method, "/api/v1/"+rest) {
+			continue
+		}
+		missing = append(missing, route.Method+" "+route.Path)
+	}
+	return missing
+}
+
+// handleOpenAPI serves the OpenAPI document
+func (s *Server) handleOpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, s.openAPI)
+}
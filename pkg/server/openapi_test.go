@@ -0,0 +1,303 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"hpa-monitor/pkg/client"
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/demo"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/leader"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/openapi"
+	"hpa-monitor/pkg/replay"
+	"hpa-monitor/pkg/transition"
+)
+
+// streamingRoutes keep the connection open, so their responses are not checked here
+var streamingRoutes = map[string]bool{
+	"GET /ws":            true,
+	"GET /api/v1/stream": true,
+}
+
+// newTestServer serves the demo scenarios with history, transitions and replay enabled
+func newTestServer(t *testing.T) (*Server, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster, err := demo.NewCluster(demo.Scenarios(), start)
+	if err != nil {
+		t.Fatalf("creating demo cluster: %v", err)
+	}
+	now := start
+	hpaMonitor := monitor.NewHPAMonitor(cluster.Client())
+	hpaMonitor.SetClock(func() time.Time { return now })
+
+	cfg := config.Default()
+	historyStore := history.NewStore(demo.SyncPeriod, 24*time.Hour)
+	tracker := transition.NewTracker("", time.Hour)
+	ctx := t.Context()
+	for i := 0; i < 40; i++ {
+		now = start.Add(time.Duration(i) * demo.SyncPeriod)
+		cluster.Tick(now)
+		statuses, err := hpaMonitor.GetHPAStatus(ctx)
+		if err != nil {
+			t.Fatalf("getting HPA status: %v", err)
+		}
+		historyStore.Record(statuses, now)
+		tracker.Observe(statuses, now)
+	}
+
+	hpas, err := cluster.Client().AutoscalingV2().HorizontalPodAutoscalers("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing HPAs: %v", err)
+	}
+	player, err := replay.NewPlayer([]replay.Frame{{Time: now, HPAs: hpas.Items}}, 1)
+	if err != nil {
+		t.Fatalf("creating replay player: %v", err)
+	}
+
+	s := NewServer(hpaMonitor, historyStore, leader.NewStandalone("test"), cfg)
+	s.SetTransitions(tracker)
+	s.SetReplay(player)
+	r := gin.New()
+	if err := s.SetupRoutes(r); err != nil {
+		t.Fatalf("setting up routes: %v", err)
+	}
+	return s, r
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	s, r := newTestServer(t)
+
+	for _, route := range UndocumentedRoutes(s.openAPI, r.Routes()) {
+		t.Errorf("route not documented: %s", route)
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		registered[route.Method+" "+ginParamPattern.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+	for _, route := range s.openAPI.Routes() {
+		if !registered[route] {
+			t.Errorf("documented operation has no route: %s", route)
+		}
+	}
+
+	for _, route := range client.Routes {
+		method, path, _ := strings.Cut(route, " ")
+		if !s.openAPI.Has(method, path) {
+			t.Errorf("client calls undocumented operation: %s", route)
+		}
+	}
+}
+
+func TestOpenAPIResponsesMatchSchemas(t *testing.T) {
+	s, r := newTestServer(t)
+	doc := s.openAPI
+
+	tests := []struct {
+		method string
+		// operation is the documented path, url the request
+		operation string
+		url       string
+		body      string
+		status    int
+	}{
+		{"GET", "/health", "/health", "", http.StatusOK},
+		{"GET", "/api/v1/hpas", "/api/v1/hpas", "", http.StatusOK},
+		{"GET", "/api/v1/hpas", "/api/v1/hpas?namespace=shop&sort=-ratio&limit=1", "", http.StatusOK},
+		{"GET", "/api/v1/hpas", "/api/v1/hpas?status=bogus", "", http.StatusBadRequest},
+		{"GET", "/api/v1/namespaces/{namespace}/hpas/{name}", "/api/v1/namespaces/shop/hpas/checkout", "", http.StatusOK},
+		{"GET", "/api/v1/namespaces/{namespace}/hpas/{name}", "/api/v1/namespaces/shop/hpas/missing", "", http.StatusNotFound},
+		{"GET", "/api/v1/namespaces/{namespace}/hpas/{name}/recommendations", "/api/v1/namespaces/shop/hpas/checkout/recommendations", "", http.StatusOK},
+		{"GET", "/api/v1/namespaces/{namespace}/hpas/{name}/recommendations", "/api/v1/namespaces/shop/hpas/missing/recommendations", "", http.StatusNotFound},
+		{"GET", "/api/v1/namespaces/{namespace}/hpas/{name}/conditions", "/api/v1/namespaces/shop/hpas/checkout/conditions", "", http.StatusOK},
+		{"GET", "/api/v1/namespaces/{namespace}/hpas/{name}/conditions", "/api/v1/namespaces/shop/hpas/missing/conditions", "", http.StatusNotFound},
+		{"GET", "/api/v1/transitions", "/api/v1/transitions", "", http.StatusOK},
+		{"GET", "/api/v1/transitions", "/api/v1/transitions?since=bogus", "", http.StatusBadRequest},
+		{"GET", "/api/v1/events/summary", "/api/v1/events/summary", "", http.StatusOK},
+		{"GET", "/api/v1/export", "/api/v1/export?format=ndjson&history=true", "", http.StatusOK},
+		{"GET", "/api/v1/export", "/api/v1/export?format=csv", "", http.StatusOK},
+		{"GET", "/api/v1/export", "/api/v1/export?format=bogus", "", http.StatusBadRequest},
+		{"GET", "/api/v1/snapshot.html", "/api/v1/snapshot.html?window=1h", "", http.StatusOK},
+		{"GET", "/api/v1/snapshot.html", "/api/v1/snapshot.html?window=bogus", "", http.StatusBadRequest},
+		{"POST", "/api/v1/simulate", "/api/v1/simulate",
+			`{"namespace":"shop","name":"checkout","series":[{"offset":0,"value":100},{"offset":300,"value":400}]}`, http.StatusOK},
+		{"POST", "/api/v1/simulate", "/api/v1/simulate", `{"series":[]}`, http.StatusBadRequest},
+		{"POST", "/api/v1/simulate", "/api/v1/simulate",
+			`{"namespace":"shop","name":"missing","series":[{"offset":0,"value":100}]}`, http.StatusNotFound},
+		{"GET", "/api/v1/config", "/api/v1/config", "", http.StatusOK},
+		{"GET", "/api/v1/version", "/api/v1/version", "", http.StatusOK},
+		{"GET", "/api/v1/replay", "/api/v1/replay", "", http.StatusOK},
+		{"POST", "/api/v1/replay", "/api/v1/replay", `{"paused":true}`, http.StatusOK},
+		{"POST", "/api/v1/replay", "/api/v1/replay", `{"speed":-1}`, http.StatusBadRequest},
+		{"GET", "/api/v1/openapi.json", "/api/v1/openapi.json", "", http.StatusOK},
+		{"GET", "/api/hpa", "/api/hpa", "", http.StatusOK},
+		{"GET", "/api/hpa/{namespace}/{name}/recommendations", "/api/hpa/shop/checkout/recommendations", "", http.StatusOK},
+		{"GET", "/api/hpa/{namespace}/{name}/conditions", "/api/hpa/shop/checkout/conditions", "", http.StatusOK},
+	}
+
+	covered := make(map[string]bool)
+	for _, test := range tests {
+		t.Run(test.method+" "+test.url, func(t *testing.T) {
+			covered[test.method+" "+test.operation] = true
+			op := doc.Paths[test.operation][strings.ToLower(test.method)]
+			if op == nil {
+				t.Fatalf("%s %s is not documented", test.method, test.operation)
+			}
+
+			req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.body))
+			if test.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != test.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, test.status, rec.Body.String())
+			}
+			response, ok := op.Responses[fmt.Sprint(rec.Code)]
+			if !ok {
+				t.Fatalf("status %d is not documented", rec.Code)
+			}
+			contentType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+			media, ok := response.Content[contentType]
+			if !ok {
+				t.Fatalf("content type %q is not documented", contentType)
+			}
+
+			switch contentType {
+			case "application/json":
+				var value interface{}
+				if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+					t.Fatalf("decoding body: %v", err)
+				}
+				for _, problem := range validate(doc, media.Schema, value, "body") {
+					t.Error(problem)
+				}
+			case "application/x-ndjson":
+				scanner := bufio.NewScanner(bytes.NewReader(rec.Body.Bytes()))
+				for line := 1; scanner.Scan(); line++ {
+					var value interface{}
+					if err := json.Unmarshal(scanner.Bytes(), &value); err != nil {
+						t.Fatalf("decoding line %d: %v", line, err)
+					}
+					for _, problem := range validate(doc, media.Schema, value, fmt.Sprintf("line %d", line)) {
+						t.Error(problem)
+					}
+				}
+			}
+		})
+	}
+
+	for _, route := range doc.Routes() {
+		if !covered[route] && !streamingRoutes[route] {
+			t.Errorf("no response checked for %s", route)
+		}
+	}
+}
+
+// validate returns one line per way value does not match schema
+func validate(doc *openapi.Document, schema openapi.Schema, value interface{}, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		component, ok := doc.Components.Schemas[name]
+		if !ok {
+			return []string{fmt.Sprintf("%s: unknown reference %s", path, ref)}
+		}
+		return validate(doc, component, value, path)
+	}
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || len(schema) == 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: null is not nullable", path)}
+	}
+	if all, ok := schema["allOf"].([]openapi.Schema); ok {
+		var problems []string
+		for _, sub := range all {
+			problems = append(problems, validate(doc, sub, value, path)...)
+		}
+		return problems
+	}
+	if enum, ok := schema["enum"].([]string); ok {
+		if s, isString := value.(string); !isString || !slices.Contains(enum, s) {
+			return []string{fmt.Sprintf("%s: %v is not one of %v", path, value, enum)}
+		}
+	}
+
+	mismatch := func() []string {
+		return []string{fmt.Sprintf("%s: %T is not %v", path, value, schema["type"])}
+	}
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		var problems []string
+		if required, ok := schema["required"].([]string); ok {
+			for _, name := range required {
+				if _, present := object[name]; !present {
+					problems = append(problems, fmt.Sprintf("%s: missing required property %s", path, name))
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]openapi.Schema)
+		additional, hasAdditional := schema["additionalProperties"].(openapi.Schema)
+		for name, field := range object {
+			if property, ok := properties[name]; ok {
+				problems = append(problems, validate(doc, property, field, path+"."+name)...)
+			} else if hasAdditional {
+				problems = append(problems, validate(doc, additional, field, path+"."+name)...)
+			} else if properties != nil {
+				problems = append(problems, fmt.Sprintf("%s: undocumented property %s", path, name))
+			}
+		}
+		return problems
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return mismatch()
+		}
+		items, _ := schema["items"].(openapi.Schema)
+		var problems []string
+		for i, item := range array {
+			problems = append(problems, validate(doc, items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return problems
+	case "string":
+		if _, ok := value.(string); !ok {
+			return mismatch()
+		}
+	case "integer":
+		if number, ok := value.(float64); !ok || number != math.Trunc(number) {
+			return mismatch()
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return mismatch()
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"sort"
+
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/monitor"
+)
+
+// ProtocolV2 is the WebSocket subprotocol for an initial snapshot followed by patches.
+// Clients that do not request it receive the full []HPAStatus array every interval.
+const ProtocolV2 = "hpa-monitor.v2"
+
+// protocolVersion is reported in every snapshot and patch message
+const protocolVersion = 2
+
+// Message types of the v2 protocol
+const (
+	messageTypeSnapshot = "snapshot"
+	messageTypePatch    = "patch"
+	messageTypeResync   = "resync"
+)
+
+// SnapshotMessage carries the full state; it resets the client's sequence
+type SnapshotMessage struct {
+	Type    string              `json:"type"`
+	Version int                 `json:"version"`
+	Seq     uint64              `json:"seq"`
+	HPAs    []monitor.HPAStatus `json:"hpas"`
+}
+
+// PatchMessage carries the HPAs added, updated and deleted since the previous message.
+// Deleted HPAs are keyed by namespace/name. A client that sees a gap in seq sends resync.
+type PatchMessage struct {
+	Type    string              `json:"type"`
+	Version int                 `json:"version"`
+	Seq     uint64              `json:"seq"`
+	Added   []monitor.HPAStatus `json:"added,omitempty"`
+	Updated []monitor.HPAStatus `json:"updated,omitempty"`
+	Deleted []string            `json:"deleted,omitempty"`
+}
+
+// ClientMessage is a message sent by a client, e.g. {"type": "resync"}.
+// The remaining fields are only used by subscribe messages.
+type ClientMessage struct {
+	Type            string   `json:"type"`
+	Namespaces      []string `json:"namespaces,omitempty"`
+	LabelSelector   string   `json:"labelSelector,omitempty"`
+	HPAs            []string `json:"hpas,omitempty"`
+	IntervalSeconds int      `json:"intervalSeconds,omitempty"`
+	IncludeEvents   *bool    `json:"includeEvents,omitempty"`
+}
+
+// deltaStream tracks what one client has received to compute patches
+type deltaStream struct {
+	seq  uint64
+	sent map[string][sha256.Size]byte
+}
+
+// newDeltaStream creates a stream that has sent nothing yet
+func newDeltaStream() *deltaStream {
+	return &deltaStream{sent: make(map[string][sha256.Size]byte)}
+}
+
+// snapshot returns a full snapshot and makes it the new baseline
+func (d *deltaStream) snapshot(statuses []monitor.HPAStatus) SnapshotMessage {
+	d.sent = make(map[string][sha256.Size]byte, len(statuses))
+	for _, status := range statuses {
+		d.sent[history.Key(status.Namespace, status.Name)] = hashStatus(status)
+	}
+	d.seq++
+
+	if statuses == nil {
+		statuses = []monitor.HPAStatus{}
+	}
+	return SnapshotMessage{
+		Type:    messageTypeSnapshot,
+		Version: protocolVersion,
+		Seq:     d.seq,
+		HPAs:    statuses,
+	}
+}
+
+// patch returns the changes since the previous message. An empty patch still
+// advances seq so clients can tell the stream is alive.
+func (d *deltaStream) patch(statuses []monitor.HPAStatus) PatchMessage {
+	d.seq++
+	message := PatchMessage{
+		Type:    messageTypePatch,
+		Version: protocolVersion,
+		Seq:     d.seq,
+	}
+
+	current := make(map[string][sha256.Size]byte, len(statuses))
+	for _, status := range statuses {
+		key := history.Key(status.Namespace, status.Name)
+		hash := hashStatus(status)
+		current[key] = hash
+
+		previous, ok := d.sent[key]
+		switch {
+		case !ok:
+			message.Added = append(message.Added, status)
+		case previous != hash:
+			message.Updated = append(message.Updated, status)
+		}
+	}
+	for key := range d.sent {
+		if _, ok := current[key]; !ok {
+			message.Deleted = append(message.Deleted, key)
+		}
+	}
+	sort.Strings(message.Deleted)
+
+	d.sent = current
+	return message
+}
+
+// hashStatus fingerprints an HPA status to detect changes
+func hashStatus(status monitor.HPAStatus) [sha256.Size]byte {
+	data, _ := json.Marshal(status)
+	return sha256.Sum256(data)
+}
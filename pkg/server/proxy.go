@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// forwardedPrefixPattern matches path prefixes such as /hpa-monitor, so an
+// X-Forwarded-Prefix cannot point the dashboard at another origin
+var forwardedPrefixPattern = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+$`)
+
+// basePath returns the configured route prefix without its trailing slash, "" at the root
+func (s *Server) basePath() string {
+	return strings.TrimSuffix(s.getConfig().BasePath, "/")
+}
+
+// publicBasePath returns the path prefix the browser reaches the server under: the
+// X-Forwarded-Prefix removed by a rewriting proxy such as ingress-nginx, then BASE_PATH
+func (s *Server) publicBasePath(r *http.Request) string {
+	prefix := strings.TrimSuffix(r.Header.Get("X-Forwarded-Prefix"), "/")
+	if !forwardedPrefixPattern.MatchString(prefix) {
+		prefix = ""
+	}
+	return prefix + s.basePath()
+}
+
+// isSecure reports whether the browser connected over HTTPS, directly or through a proxy
+// that sets X-Forwarded-Proto
+func isSecure(r *http.Request) bool {
+	if r.TLS != nil {
+		return true
+	}
+	// Chained proxies may send a list; the first entry is the client's scheme
+	proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
+
+// httpsRedirect redirects every request to the same host and URL on the HTTPS port
+func httpsRedirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
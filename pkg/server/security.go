@@ -0,0 +1,180 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The CSRF token is set in a cookie with the dashboard page and must be echoed in a header
+const (
+	csrfCookieName = "hpa_monitor_csrf"
+	csrfHeaderName = "X-CSRF-Token"
+)
+
+// securityHeaders sets headers for every response. The Content-Security-Policy forbids
+// everything but framing by FRAME_ANCESTORS; pages loosen it for what they load.
+func (s *Server) securityHeaders(c *gin.Context) {
+	header := c.Writer.Header()
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Referrer-Policy", "same-origin")
+	header.Set("Cross-Origin-Opener-Policy", "same-origin")
+	frameAncestors := s.frameAncestors()
+	header.Set("Content-Security-Policy", "default-src 'none'; frame-ancestors "+frameAncestors)
+	// X-Frame-Options cannot list origins, so it is only sent when framing is forbidden
+	if frameAncestors == "'none'" {
+		header.Set("X-Frame-Options", "DENY")
+	}
+	if c.Request.TLS != nil {
+		header.Set("Strict-Transport-Security", "max-age=31536000")
+	}
+	c.Next()
+}
+
+// frameAncestors returns the CSP frame-ancestors sources, 'none' unless configured
+func (s *Server) frameAncestors() string {
+	origins := s.getConfig().FrameAncestors
+	if len(origins) == 0 {
+		return "'none'"
+	}
+	sources := make([]string, 0, len(origins))
+	for _, origin := range origins {
+		sources = append(sources, strings.TrimSuffix(origin, "/"))
+	}
+	return strings.Join(sources, " ")
+}
+
+// crossOrigin answers CORS requests from ALLOWED_ORIGINS and protects unsafe methods
+// against cross-site request forgery. Browser requests must come from the server's own
+// origin with the CSRF token, or from an allowed origin; requests without browser
+// headers, such as from the Go client, are not checked.
+func (s *Server) crossOrigin(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	cors := origin != "" && !sameOrigin(c.Request, origin) && s.originAllowed(origin)
+	if origin != "" {
+		c.Writer.Header().Add("Vary", "Origin")
+	}
+	if cors {
+		header := c.Writer.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			header.Set("Access-Control-Allow-Methods", "GET, POST")
+			header.Set("Access-Control-Allow-Headers", "Content-Type, "+csrfHeaderName)
+			header.Set("Access-Control-Max-Age", "600")
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		c.Next()
+		return
+	}
+	switch {
+	case cors:
+		// The browser only sends these after a preflight the allowlist approved
+	case origin != "" && !sameOrigin(c.Request, origin):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "cross-origin request from " + origin + " is not allowed"})
+		return
+	case isBrowserRequest(c.Request) && !validCSRFToken(c.Request):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing or invalid " + csrfHeaderName + " header"})
+		return
+	}
+	c.Next()
+}
+
+// checkOrigin allows WebSocket connections from the server's own origin, from
+// ALLOWED_ORIGINS and from clients that send no Origin, which are not browsers
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || sameOrigin(r, origin) || s.originAllowed(origin)
+}
+
+// originAllowed reports whether origin is listed in ALLOWED_ORIGINS, or any origin is
+func (s *Server) originAllowed(origin string) bool {
+	for _, allowed := range s.getConfig().AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOrigin reports whether origin has the scheme, host and port the browser sent the
+// request to, taking the scheme from a TLS-terminating proxy's X-Forwarded-Proto
+func sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	scheme := "http"
+	if isSecure(r) {
+		scheme = "https"
+	}
+	server := &url.URL{Scheme: scheme, Host: r.Host}
+	return strings.EqualFold(u.Scheme, server.Scheme) &&
+		strings.EqualFold(u.Hostname(), server.Hostname()) &&
+		originPort(u) == originPort(server)
+}
+
+// originPort returns the URL's port, or its scheme's default port when it has none
+func originPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	if strings.EqualFold(u.Scheme, "https") {
+		return "443"
+	}
+	return "80"
+}
+
+// isBrowserRequest reports whether a request carries headers only browsers send, or
+// the CSRF cookie
+func isBrowserRequest(r *http.Request) bool {
+	if r.Header.Get("Origin") != "" || r.Header.Get("Sec-Fetch-Site") != "" {
+		return true
+	}
+	_, err := r.Cookie(csrfCookieName)
+	return err == nil
+}
+
+// validCSRFToken reports whether the CSRF header matches the CSRF cookie
+func validCSRFToken(r *http.Request) bool {
+	cookie, err := r.Cookie(csrfCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.Header.Get(csrfHeaderName))) == 1
+}
+
+// csrfToken returns the request's CSRF token, issuing a new one in a cookie scoped to
+// the dashboard's path when it has none
+func csrfToken(c *gin.Context, path string) string {
+	if cookie, err := c.Request.Cookie(csrfCookieName); err == nil && len(cookie.Value) == base64.RawURLEncoding.EncodedLen(32) {
+		return cookie.Value
+	}
+	token := randomString(32)
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     path,
+		HttpOnly: true,
+		Secure:   isSecure(c.Request),
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token
+}
+
+// randomString returns n random bytes, base64url encoded
+func randomString(n int) string {
+	b := make([]byte, n)
+	// crypto/rand.Read never returns an error
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSameOrigin(t *testing.T) {
+	tests := []struct {
+		host           string
+		tls            bool
+		forwardedProto string
+		origin         string
+		want           bool
+	}{
+		{"hpa.example.com", false, "", "http://hpa.example.com", true},
+		{"hpa.example.com", false, "", "http://HPA.example.com:80", true},
+		{"hpa.example.com", false, "", "https://hpa.example.com", false},
+		{"hpa.example.com", true, "", "https://hpa.example.com", true},
+		{"hpa.example.com", true, "", "http://hpa.example.com", false},
+		{"hpa.example.com", false, "https", "https://hpa.example.com", true},
+		{"hpa.example.com", false, "https", "http://hpa.example.com", false},
+		{"hpa.example.com", false, "https, http", "https://hpa.example.com:443", true},
+		{"hpa.example.com:8443", true, "", "https://hpa.example.com:8443", true},
+		{"hpa.example.com:8443", true, "", "https://hpa.example.com", false},
+		{"localhost:8080", false, "", "http://localhost:8081", false},
+		{"[::1]:8080", false, "", "http://[::1]:8080", true},
+		{"hpa.example.com", false, "", "http://evil.example.com", false},
+		{"hpa.example.com", false, "", "null", false},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Host = test.host
+		if test.tls {
+			r.TLS = &tls.ConnectionState{}
+		} else {
+			r.TLS = nil
+		}
+		if test.forwardedProto != "" {
+			r.Header.Set("X-Forwarded-Proto", test.forwardedProto)
+		}
+		if got := sameOrigin(r, test.origin); got != test.want {
+			t.Errorf("origin %s for host %s (tls %v, X-Forwarded-Proto %q): got %v, want %v",
+				test.origin, test.host, test.tls, test.forwardedProto, got, test.want)
+		}
+	}
+}
@@ -2,15 +2,31 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"hpa-monitor/pkg/api"
+	"hpa-monitor/pkg/certs"
 	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/leader"
 	"hpa-monitor/pkg/logger"
 	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/openapi"
+	"hpa-monitor/pkg/recommend"
+	"hpa-monitor/pkg/replay"
+	"hpa-monitor/pkg/simulate"
+	"hpa-monitor/pkg/transition"
+	"hpa-monitor/web"
 )
 
 // Version is set by build flags
@@ -19,52 +35,245 @@ var Version = "dev"
 // Server handles HTTP requests and WebSocket connections
 type Server struct {
 	hpaMonitor *monitor.HPAMonitor
+	history    *history.Store
+	replay     *replay.Player
+	tracker    *transition.Tracker
+	elector    *leader.Elector
 	config     *config.Config
 	upgrader   websocket.Upgrader
+	openAPI    *openapi.Document
+	assets     *assetSet
+	certs      *certs.Reloader
+
+	mu      sync.RWMutex
+	clients map[chan interface{}]struct{}
+
+	// shutdown is closed when the server stops, telling WebSocket handlers to close
+	shutdown    chan struct{}
+	connections sync.WaitGroup
+}
+
+// ConfigReloadedMessage is sent to WebSocket clients when the configuration changes
+type ConfigReloadedMessage struct {
+	Type      string          `json:"type"`
+	Changes   []config.Change `json:"changes"`
+	Timestamp time.Time       `json:"timestamp"`
 }
 
 // NewServer creates a new server instance
-func NewServer(hpaMonitor *monitor.HPAMonitor, cfg *config.Config) *Server {
+func NewServer(hpaMonitor *monitor.HPAMonitor, historyStore *history.Store, elector *leader.Elector, cfg *config.Config) *Server {
 	log := logger.GetLogger()
 	
 	server := &Server{
 		hpaMonitor: hpaMonitor,
+		history:    historyStore,
+		elector:    elector,
 		config:     cfg,
+		clients:    make(map[chan interface{}]struct{}),
+		shutdown:   make(chan struct{}),
 		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
+			Subprotocols:      []string{ProtocolV2},
+			EnableCompression: cfg.WebSocketCompression,
 		},
 	}
+	server.upgrader.CheckOrigin = server.checkOrigin
 	
 	log.WithField("port", cfg.Port).Info("Server instance created")
 	return server
 }
 
+// SetReplay enables replay controls backed by the given player
+func (s *Server) SetReplay(player *replay.Player) {
+	s.replay = player
+}
+
+// TransitionsMessage is sent to WebSocket clients when HPAs scale
+type TransitionsMessage struct {
+	Type        string                       `json:"type"`
+	Transitions []transition.ScaleTransition `json:"transitions"`
+}
+
+// SetTLS serves HTTP and gRPC over TLS with the reloader's certificate, requiring client
+// certificates when it has client CAs
+func (s *Server) SetTLS(reloader *certs.Reloader) {
+	s.certs = reloader
+}
+
+// SetTransitions enables the scale transition API backed by the given tracker
+func (s *Server) SetTransitions(tracker *transition.Tracker) {
+	s.tracker = tracker
+}
+
+// BroadcastTransitions sends new scale transitions to WebSocket clients
+func (s *Server) BroadcastTransitions(transitions []transition.ScaleTransition) {
+	s.broadcast(TransitionsMessage{
+		Type:        "transitions",
+		Transitions: transitions,
+	})
+}
+
+// SetConfig replaces the configuration after a reload and notifies WebSocket clients
+func (s *Server) SetConfig(cfg *config.Config, changes []config.Change) {
+	s.mu.Lock()
+	s.config = cfg
+	s.mu.Unlock()
+
+	s.broadcast(ConfigReloadedMessage{
+		Type:      "configReloaded",
+		Changes:   changes,
+		Timestamp: time.Now(),
+	})
+}
+
+// getConfig returns the current configuration
+func (s *Server) getConfig() *config.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// broadcast queues a message for every connected WebSocket client, dropping it for slow clients
+func (s *Server) broadcast(message interface{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for client := range s.clients {
+		select {
+		case client <- message:
+		default:
+		}
+	}
+}
+
+// applyReload resets ticker to the reloaded interval when message is a configuration reload
+// that changes the subscription's interval
+func (s *Server) applyReload(message interface{}, sub *subscription, ticker *time.Ticker) {
+	if _, ok := message.(ConfigReloadedMessage); !ok {
+		return
+	}
+	if sub.reload(time.Duration(s.getConfig().WebSocketInterval) * time.Second) {
+		ticker.Reset(sub.interval)
+	}
+}
+
+// register adds a WebSocket client to receive broadcasts
+func (s *Server) register() chan interface{} {
+	client := make(chan interface{}, 8)
+	s.mu.Lock()
+	s.clients[client] = struct{}{}
+	s.mu.Unlock()
+	return client
+}
+
+// unregister removes a WebSocket client
+func (s *Server) unregister(client chan interface{}) {
+	s.mu.Lock()
+	delete(s.clients, client)
+	s.mu.Unlock()
+}
+
 // SetupRoutes configures the HTTP routes
-func (s *Server) SetupRoutes(r *gin.Engine) {
-	// Serve static files from web directory
-	r.StaticFile("/style.css", "./web/style.css")
-	r.LoadHTMLGlob("web/*")
+func (s *Server) SetupRoutes(r *gin.Engine) error {
+	// Serve the dashboard from the embedded assets, or from the web directory when set
+	webDir := s.getConfig().WebDir
+	assets, err := newAssetSet(web.FS(webDir), webDir != "")
+	if err != nil {
+		return err
+	}
+	s.assets = assets
+
+	// Security headers and origin checks also apply to unmatched routes and CORS preflights
+	r.Use(s.securityHeaders, s.crossOrigin)
+
+	// Every route is served under the base path
+	basePath := s.basePath()
+	root := r.Group(basePath)
+	root.GET("/", s.handleIndex)
+	root.GET("/style.css", s.handleAsset)
+	root.HEAD("/style.css", s.handleAsset)
+	root.GET(assetPathPrefix+":file", s.handleAsset)
+	root.HEAD(assetPathPrefix+":file", s.handleAsset)
 
 	// Routes
-	r.GET("/", s.handleIndex)
-	r.GET("/api/hpa", s.handleHTTP)
-	r.GET("/api/config", s.handleConfig)
-	r.GET("/api/version", s.handleVersion)
+	s.setupAPIRoutes(root)
+
+	// Catch handlers added without documenting them
+	s.openAPI = OpenAPIDocument()
+	routes := r.Routes()
+	for i := range routes {
+		routes[i].Path = strings.TrimPrefix(routes[i].Path, basePath)
+	}
+	for _, route := range UndocumentedRoutes(s.openAPI, routes) {
+		logger.GetLogger().WithField("route", route).Warn("Route missing from the OpenAPI document")
+	}
+	return nil
+}
+
+// setupAPIRoutes registers the API, WebSocket and health routes
+func (s *Server) setupAPIRoutes(r gin.IRouter) {
 	r.GET("/ws", s.handleWebSocket)
 	r.GET("/health", s.handleHealth)
+
+	v1 := r.Group("/api/v1")
+	v1.GET("/hpas", s.handleListHPAs)
+	v1.GET("/namespaces/:namespace/hpas/:name", s.handleGetHPA)
+	v1.GET("/namespaces/:namespace/hpas/:name/recommendations", s.leaderOnly, s.handleRecommendations)
+	v1.GET("/namespaces/:namespace/hpas/:name/conditions", s.leaderOnly, s.handleConditionHistory)
+	s.setupSharedRoutes(v1)
+
+	// Unversioned routes kept for existing clients; /api/hpa still returns a plain array
+	unversioned := r.Group("/api")
+	unversioned.GET("/hpa", s.handleHTTP)
+	unversioned.GET("/hpa/:namespace/:name/recommendations", s.leaderOnly, s.handleRecommendations)
+	unversioned.GET("/hpa/:namespace/:name/conditions", s.leaderOnly, s.handleConditionHistory)
+	s.setupSharedRoutes(unversioned)
+}
+
+// setupSharedRoutes registers the routes whose paths are the same under /api and /api/v1
+func (s *Server) setupSharedRoutes(group *gin.RouterGroup) {
+	group.GET("/events/summary", s.handleEventSummary)
+	group.GET("/export", s.handleExport)
+	group.GET("/snapshot.html", s.handleSnapshot)
+	group.POST("/simulate", s.handleSimulate)
+	group.GET("/config", s.handleConfig)
+	group.GET("/version", s.handleVersion)
+	group.GET("/stream", s.handleStream)
+	group.GET("/openapi.json", s.handleOpenAPI)
+	if s.tracker != nil {
+		group.GET("/transitions", s.leaderOnly, s.handleTransitions)
+	}
+	if s.replay != nil {
+		group.GET("/replay", s.handleReplayStatus)
+		group.POST("/replay", s.handleReplayControl)
+	}
+}
+
+// leaderOnly rejects requests for history and transitions on followers, which do not
+// record them, naming the leader that does
+func (s *Server) leaderOnly(c *gin.Context) {
+	if s.isLeader() {
+		c.Next()
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": s.notLeaderMessage()})
 }
 
-// handleIndex serves the main dashboard page
-func (s *Server) handleIndex(c *gin.Context) {
-	c.HTML(http.StatusOK, "index.html", nil)
+// isLeader reports whether this replica records history and transitions
+func (s *Server) isLeader() bool {
+	return s.elector == nil || s.elector.IsLeader()
+}
+
+// notLeaderMessage explains that a follower has no history or transitions
+func (s *Server) notLeaderMessage() string {
+	if leader := s.elector.Status().Leader; leader != "" {
+		return "history and transitions are only recorded by the leader, " + leader
+	}
+	return "history and transitions are only recorded by the leader, and none is elected"
 }
 
 // handleHTTP handles HTTP API requests for HPA status
 func (s *Server) handleHTTP(c *gin.Context) {
 	log := logger.GetLogger()
-	ctx := context.Background()
+	ctx := c.Request.Context()
 	
 	hpaStatuses, err := s.hpaMonitor.GetHPAStatus(ctx)
 	if err != nil {
@@ -77,11 +286,166 @@ func (s *Server) handleHTTP(c *gin.Context) {
 	c.JSON(http.StatusOK, hpaStatuses)
 }
 
+// handleRecommendations handles recommendation requests for a single HPA
+func (s *Server) handleRecommendations(c *gin.Context) {
+	log := logger.GetLogger()
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	since := s.hpaMonitor.Now().Add(-s.history.Retention())
+	samples := s.history.Samples(namespace, name, since)
+	if len(samples) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no history recorded for " + history.Key(namespace, name)})
+		return
+	}
+
+	report := recommend.Analyze(namespace, name, samples)
+	log.WithFields(logger.Fields{
+		"namespace":       namespace,
+		"name":            name,
+		"samples":         report.Samples,
+		"recommendations": len(report.Recommendations),
+	}).Debug("Recommendations computed")
+	c.JSON(http.StatusOK, report)
+}
+
+// handleConditionHistory returns the condition transitions recorded for a single HPA
+func (s *Server) handleConditionHistory(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	since := s.hpaMonitor.Now().Add(-s.history.Retention())
+	if len(s.history.Samples(namespace, name, since)) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no history recorded for " + history.Key(namespace, name)})
+		return
+	}
+
+	c.JSON(http.StatusOK, api.ConditionHistory{
+		Namespace:   namespace,
+		Name:        name,
+		Transitions: s.history.Transitions(namespace, name, since),
+	})
+}
+
+// handleEventSummary returns HPA event counts per category across the cluster
+func (s *Server) handleEventSummary(c *gin.Context) {
+	log := logger.GetLogger()
+
+	hpaStatuses, err := s.hpaMonitor.GetHPAStatus(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error("Failed to get HPA status for event summary")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, api.EventSummary{
+		Categories: monitor.SummarizeEvents(hpaStatuses),
+	})
+}
+
+// handleTransitions returns scale transitions since an RFC3339 time or a duration ago, e.g. ?since=1h
+func (s *Server) handleTransitions(c *gin.Context) {
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		if ago, err := time.ParseDuration(raw); err == nil {
+			since = s.hpaMonitor.Now().Add(-ago)
+		} else if at, err := time.Parse(time.RFC3339, raw); err == nil {
+			since = at
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC3339 timestamp or a duration such as 1h"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, api.TransitionList{
+		Transitions: s.tracker.Since(since),
+	})
+}
+
+// maxSimulateBodyBytes bounds simulation requests, which carry a manifest and series
+const maxSimulateBodyBytes = 1 << 20
+
+// handleSimulate replays the HPA controller algorithm over a hypothetical metric series
+func (s *Server) handleSimulate(c *gin.Context) {
+	log := logger.GetLogger()
+
+	var req api.SimulateRequest
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSimulateBodyBytes)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	switch {
+	case req.Manifest != "":
+		parsed, err := simulate.ParseManifest([]byte(req.Manifest))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		hpa = parsed
+	case req.Namespace != "" && req.Name != "":
+		existing, err := s.hpaMonitor.GetHPA(c.Request.Context(), req.Namespace, req.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		hpa = existing
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "either manifest or namespace and name are required"})
+		return
+	}
+
+	// An explicit tolerance, including zero, overrides the one the HPA is monitored with.
+	// A posted manifest may not exist, so its namespace is not looked up.
+	var tolerance float64
+	switch {
+	case req.Tolerance != nil:
+		tolerance = *req.Tolerance
+	case req.Manifest != "":
+		tolerance = s.hpaMonitor.ManifestTolerance(hpa)
+	default:
+		tolerance = s.hpaMonitor.EffectiveTolerance(c.Request.Context(), hpa)
+	}
+
+	result, err := simulate.Run(simulate.Input{
+		HPA:             hpa,
+		Series:          req.Series,
+		InitialReplicas: req.InitialReplicas,
+		StepSeconds:     req.StepSeconds,
+		Tolerance:       tolerance,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.WithFields(logger.Fields{
+		"namespace": hpa.Namespace,
+		"name":      hpa.Name,
+		"steps":     len(result.Steps),
+	}).Debug("Simulation completed")
+	c.JSON(http.StatusOK, result)
+}
+
 // handleConfig handles configuration API requests
 func (s *Server) handleConfig(c *gin.Context) {
-	configResponse := gin.H{
-		"websocketInterval": s.config.WebSocketInterval,
-		"tolerance":         s.config.Tolerance,
+	cfg := s.getConfig()
+	// An empty list means every namespace is watched
+	namespaces := s.hpaMonitor.GetNamespaces()
+	if namespaces == nil {
+		namespaces = []string{}
+	}
+	configResponse := api.ConfigInfo{
+		WebSocketInterval: cfg.WebSocketInterval,
+		Tolerance:         s.hpaMonitor.GetTolerance(),
+		Namespaces:        namespaces,
+		Replay:            s.replay != nil,
 	}
 	c.JSON(http.StatusOK, configResponse)
 }
@@ -99,62 +463,348 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 		return
 	}
 	defer conn.Close()
+	s.connections.Add(1)
+	defer s.connections.Done()
+
+	// Only used when the client negotiated permessage-deflate
+	conn.EnableWriteCompression(true)
 
-	log.WithField("client_ip", clientIP).Info("WebSocket connection established")
+	protocol := conn.Subprotocol()
+	log.WithFields(logger.Fields{
+		"client_ip": clientIP,
+		"protocol":  protocol,
+	}).Info("WebSocket connection established")
 
-	ticker := time.NewTicker(time.Duration(s.config.WebSocketInterval) * time.Second)
+	// The request context is not cancelled for hijacked connections, so cancel it
+	// once the client goes away. Reading also processes the client's close frames.
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	requests := make(chan ClientMessage, 8)
+	go func() {
+		defer cancel()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request ClientMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				log.WithField("client_ip", clientIP).WithError(err).Debug("Ignoring invalid websocket message")
+				continue
+			}
+			select {
+			case requests <- request:
+			default:
+			}
+		}
+	}()
+
+	messages := s.register()
+	defer s.unregister(messages)
+
+	// Every HPA is sent until the client subscribes with a filter, at the server's
+	// interval until the client chooses its own
+	sub := defaultSubscription(time.Duration(s.getConfig().WebSocketInterval) * time.Second)
+	visible := make(map[string]bool)
+
+	// v2 clients get a snapshot right away and patches afterwards
+	var stream *deltaStream
+	if protocol == ProtocolV2 {
+		stream = newDeltaStream()
+		if !s.sendSnapshot(ctx, conn, stream, sub, visible, clientIP) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(sub.interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			ctx := context.Background()
-			hpaStatuses, err := s.hpaMonitor.GetHPAStatus(ctx)
-			if err != nil {
+		case <-ctx.Done():
+			log.WithField("client_ip", clientIP).Info("WebSocket connection closed")
+			return
+		case <-s.shutdown:
+			closeMessage := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+			if err := conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second)); err != nil {
+				log.WithField("client_ip", clientIP).WithError(err).Debug("Failed to send websocket close frame")
+			}
+			return
+		case request := <-requests:
+			switch request.Type {
+			case messageTypeResync:
+				if stream == nil {
+					continue
+				}
+				log.WithField("client_ip", clientIP).Debug("WebSocket client requested resync")
+				if !s.sendSnapshot(ctx, conn, stream, sub, visible, clientIP) {
+					return
+				}
+			case messageTypeSubscribe:
+				cfg := s.getConfig()
+				next, err := newSubscription(request,
+					time.Duration(cfg.WebSocketInterval)*time.Second,
+					time.Duration(cfg.WebSocketMinInterval)*time.Second,
+					time.Duration(cfg.WebSocketMaxInterval)*time.Second)
+				if err != nil {
+					log.WithField("client_ip", clientIP).WithError(err).Debug("Rejected websocket subscription")
+					// Legacy connections only ever receive HPA arrays
+					if stream == nil {
+						continue
+					}
+					if err := conn.WriteJSON(ErrorMessage{Type: messageTypeError, Message: err.Error()}); err != nil {
+						return
+					}
+					continue
+				}
+
+				sub = next
+				ticker.Reset(sub.interval)
 				log.WithFields(logger.Fields{
 					"client_ip": clientIP,
-				}).WithError(err).Error("Error getting HPA status for websocket")
+					"filter":    sub.confirmation,
+				}).Debug("WebSocket client subscribed")
+
+				// Send the filtered state right away instead of waiting for the next tick;
+				// legacy connections get it without the confirmation
+				if stream != nil {
+					if err := conn.WriteJSON(sub.confirmation); err != nil {
+						return
+					}
+					if !s.sendSnapshot(ctx, conn, stream, sub, visible, clientIP) {
+						return
+					}
+				} else if !s.sendUpdate(ctx, conn, nil, sub, visible, clientIP) {
+					return
+				}
+			}
+		case message := <-messages:
+			s.applyReload(message, sub, ticker)
+			// Legacy connections only ever receive HPA arrays
+			if stream == nil {
 				continue
 			}
-
-			if err := conn.WriteJSON(hpaStatuses); err != nil {
+			if sub.filtered() {
+				var ok bool
+				if message, ok = filterBroadcast(message, visible); !ok {
+					continue
+				}
+			}
+			if err := conn.WriteJSON(message); err != nil {
 				log.WithFields(logger.Fields{
 					"client_ip": clientIP,
 				}).WithError(err).Error("Error writing JSON to websocket")
 				return
 			}
-			
-			log.WithFields(logger.Fields{
-				"client_ip": clientIP,
-				"hpa_count": len(hpaStatuses),
-			}).Debug("WebSocket data sent")
+		case <-ticker.C:
+			if !s.sendUpdate(ctx, conn, stream, sub, visible, clientIP) {
+				return
+			}
+		}
+	}
+}
+
+// sendUpdate writes the filtered HPA statuses, as an array on v1 connections and as a patch
+// on v2 connections; it returns false when the connection is unusable
+func (s *Server) sendUpdate(ctx context.Context, conn *websocket.Conn, stream *deltaStream, sub *subscription, visible map[string]bool, clientIP string) bool {
+	log := logger.GetLogger()
+
+	hpaStatuses, err := s.hpaMonitor.ListHPAStatus(ctx, sub.statusOptions())
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		log.WithFields(logger.Fields{
+			"client_ip": clientIP,
+		}).WithError(err).Error("Error getting HPA status for websocket")
+		return true
+	}
+	hpaStatuses = sub.apply(hpaStatuses)
+	setVisible(visible, hpaStatuses)
+
+	var payload interface{} = hpaStatuses
+	if stream != nil {
+		payload = stream.patch(hpaStatuses)
+	}
+	if err := conn.WriteJSON(payload); err != nil {
+		log.WithFields(logger.Fields{
+			"client_ip": clientIP,
+		}).WithError(err).Error("Error writing JSON to websocket")
+		return false
+	}
+
+	log.WithFields(logger.Fields{
+		"client_ip": clientIP,
+		"hpa_count": len(hpaStatuses),
+	}).Debug("WebSocket data sent")
+	return true
+}
+
+// sendSnapshot writes a full snapshot on a v2 connection; it returns false when the connection is unusable
+func (s *Server) sendSnapshot(ctx context.Context, conn *websocket.Conn, stream *deltaStream, sub *subscription, visible map[string]bool, clientIP string) bool {
+	log := logger.GetLogger()
+
+	hpaStatuses, err := s.hpaMonitor.ListHPAStatus(ctx, sub.statusOptions())
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
 		}
+		// Keep the connection; the next patch adds every HPA to an empty baseline
+		log.WithField("client_ip", clientIP).WithError(err).Error("Error getting HPA status for websocket snapshot")
+		hpaStatuses = nil
+	}
+	hpaStatuses = sub.apply(hpaStatuses)
+	setVisible(visible, hpaStatuses)
+
+	if err := conn.WriteJSON(stream.snapshot(hpaStatuses)); err != nil {
+		log.WithField("client_ip", clientIP).WithError(err).Error("Error writing JSON to websocket")
+		return false
 	}
+	return true
+}
+
+// handleReplayStatus returns the current replay playback position
+func (s *Server) handleReplayStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, s.replay.Status())
+}
+
+// handleReplayControl changes replay speed, pauses or resumes, and seeks
+func (s *Server) handleReplayControl(c *gin.Context) {
+	log := logger.GetLogger()
+
+	var req api.ReplayControl
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Speed != nil {
+		if err := s.replay.SetSpeed(*req.Speed); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.Seek != nil {
+		target, err := time.Parse(time.RFC3339, *req.Seek)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "seek must be an RFC3339 timestamp"})
+			return
+		}
+		if err := s.replay.Seek(target); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.Paused != nil {
+		s.replay.SetPaused(*req.Paused)
+	}
+
+	status := s.replay.Status()
+	log.WithFields(logger.Fields{
+		"position": status.Position.Format(time.RFC3339),
+		"speed":    status.Speed,
+		"paused":   status.Paused,
+	}).Info("Replay playback updated")
+	c.JSON(http.StatusOK, status)
 }
 
 // handleVersion handles version API requests
 func (s *Server) handleVersion(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"version": Version})
+	c.JSON(http.StatusOK, api.VersionInfo{
+		Version: Version,
+		Leader:  s.elector.Status(),
+	})
 }
 
 // handleHealth handles health check requests
 func (s *Server) handleHealth(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+	c.JSON(http.StatusOK, api.Health{Status: "healthy"})
 }
 
-// Start starts the HTTP server
-func (s *Server) Start() error {
+// Start serves HTTP until ctx is cancelled, then closes WebSocket connections with a
+// close frame and drains in-flight requests for up to the configured shutdown timeout
+func (s *Server) Start(ctx context.Context) error {
 	log := logger.GetLogger()
 	
 	// Setup Gin router
 	r := gin.Default()
-	s.SetupRoutes(r)
+	if err := s.SetupRoutes(r); err != nil {
+		return err
+	}
 	
+	cfg := s.getConfig()
 	log.WithFields(logger.Fields{
-		"port":               s.config.Port,
-		"websocket_interval": s.config.WebSocketInterval,
-		"tolerance":          s.config.Tolerance,
+		"port":               cfg.Port,
+		"tls":                s.certs != nil,
+		"websocket_interval": cfg.WebSocketInterval,
+		"tolerance":          cfg.Tolerance,
 	}).Info("Starting HPA Monitor server")
-	
-	return r.Run(":" + s.config.Port)
-}
\ No newline at end of file
+
+	httpServer := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: r,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if s.certs != nil {
+			httpServer.TLSConfig = s.certs.TLSConfig("h2", "http/1.1")
+			serveErr <- httpServer.ListenAndServeTLS("", "")
+			return
+		}
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	// Plain HTTP requests on the redirect port are sent to HTTPS
+	var redirectServer *http.Server
+	if s.certs != nil && cfg.HTTPRedirectPort != "" {
+		redirectServer = &http.Server{
+			Addr:    ":" + cfg.HTTPRedirectPort,
+			Handler: httpsRedirect(cfg.Port),
+		}
+		go func() {
+			if err := redirectServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.WithError(err).Error("HTTP redirect server failed")
+			}
+		}()
+		log.WithField("port", cfg.HTTPRedirectPort).Info("Redirecting HTTP to HTTPS")
+	}
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := time.Duration(s.getConfig().ShutdownTimeout) * time.Second
+	log.WithField("timeout", timeout.String()).Info("Shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Shutdown does not track hijacked WebSocket connections, so close them separately
+	close(s.shutdown)
+	if redirectServer != nil {
+		redirectServer.Shutdown(shutdownCtx)
+	}
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.connections.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		return errors.New("timed out waiting for websocket connections to close")
+	}
+
+	if err := <-serveErr; err != http.ErrServerClosed {
+		return err
+	}
+	log.Info("Server stopped")
+	return nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/monitor"
+)
+
+func TestWebSocketConfigReload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientset := fake.NewSimpleClientset(
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+	)
+	cfg := config.Default()
+	cfg.WebSocketInterval = 60
+	s := NewServer(monitor.NewHPAMonitor(clientset), nil, nil, cfg)
+	r := gin.New()
+	s.setupAPIRoutes(r)
+	httpServer := httptest.NewServer(r)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+	legacy, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dialing v1: %v", err)
+	}
+	defer legacy.Close()
+	v2Dialer := websocket.Dialer{Subprotocols: []string{ProtocolV2}}
+	v2, _, err := v2Dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dialing v2: %v", err)
+	}
+	defer v2.Close()
+
+	// read returns the next message as raw JSON, failing after timeout
+	read := func(conn *websocket.Conn, timeout time.Duration) json.RawMessage {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		var message json.RawMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("reading message: %v", err)
+		}
+		return message
+	}
+	messageType := func(message json.RawMessage) string {
+		var typed struct {
+			Type string `json:"type"`
+		}
+		json.Unmarshal(message, &typed)
+		return typed.Type
+	}
+
+	if got := messageType(read(v2, 5*time.Second)); got != messageTypeSnapshot {
+		t.Fatalf("first v2 message %q, want %q", got, messageTypeSnapshot)
+	}
+
+	next := *cfg
+	next.WebSocketInterval = 1
+	s.SetConfig(&next, []config.Change{{Key: "websocketInterval", Old: 60, New: 1}})
+
+	if got := messageType(read(v2, 5*time.Second)); got != "configReloaded" {
+		t.Errorf("v2 message after reload %q, want configReloaded", got)
+	}
+	// The v1 connection only gets HPA arrays, and at the reloaded interval instead of after 60s
+	if message := read(legacy, 5*time.Second); !strings.HasPrefix(string(message), "[") {
+		t.Errorf("v1 message after reload %s, want an HPA array", message)
+	}
+}
+
+func TestWebSocketSubscribeOnLegacyConnection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientset := fake.NewSimpleClientset(
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "report-worker"}},
+	)
+	cfg := config.Default()
+	cfg.WebSocketInterval = 60
+	s := NewServer(monitor.NewHPAMonitor(clientset), nil, nil, cfg)
+	r := gin.New()
+	s.setupAPIRoutes(r)
+	httpServer := httptest.NewServer(r)
+	defer httpServer.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("dialing v1: %v", err)
+	}
+	defer conn.Close()
+
+	clientset.ClearActions()
+	includeEvents := false
+	if err := conn.WriteJSON(ClientMessage{Type: messageTypeSubscribe, Namespaces: []string{"shop"}, IncludeEvents: &includeEvents}); err != nil {
+		t.Fatalf("subscribing: %v", err)
+	}
+
+	// The filtered array comes right away, without a subscribed message
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var statuses []monitor.HPAStatus
+	if err := conn.ReadJSON(&statuses); err != nil {
+		t.Fatalf("reading HPA array: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Name != "checkout" {
+		t.Errorf("HPAs %+v, want only shop/checkout", statuses)
+	}
+
+	// Only the subscribed namespace is polled, and its events are not listed
+	var calls []string
+	for _, action := range clientset.Actions() {
+		calls = append(calls, action.GetVerb()+" "+action.GetResource().Resource+" "+action.GetNamespace())
+	}
+	if got, want := strings.Join(calls, ", "), "list horizontalpodautoscalers shop, get namespaces "; got != want {
+		t.Errorf("API calls %s, want %s", got, want)
+	}
+}
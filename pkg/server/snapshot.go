@@ -0,0 +1,66 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"hpa-monitor/pkg/export"
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/snapshot"
+)
+
+// handleSnapshot renders the current dashboard state as a self-contained HTML file, e.g.
+// /api/v1/snapshot.html?namespace=shop&window=6h. It accepts the filters and sort order
+// of /api/v1/hpas; window sets the history drawn in sparklines, 24h by default.
+func (s *Server) handleSnapshot(c *gin.Context) {
+	log := logger.GetLogger()
+
+	window := snapshot.DefaultWindow
+	if raw := c.Query("window"); raw != "" {
+		var err error
+		if window, err = export.ParseWindow(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	hpaStatuses, err := s.hpaMonitor.GetHPAStatus(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error("Failed to get HPA status for snapshot")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if hpaStatuses, err = FilterHPAs(hpaStatuses, c.Request.URL.Query()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := s.hpaMonitor.Now()
+	// Followers record no history, so their snapshots have no sparklines
+	var store *history.Store
+	if s.isLeader() {
+		store = s.history
+	}
+	var page bytes.Buffer
+	err = snapshot.Render(&page, s.assets.fsys, hpaStatuses, snapshot.Options{
+		Cluster:     s.getConfig().ClusterName,
+		GeneratedAt: now,
+		Store:       store,
+		Window:      window,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to render snapshot")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("hpa-snapshot-%s.html", now.UTC().Format("20060102T150405Z"))
+	c.Header("Content-Disposition", `inline; filename="`+filename+`"`)
+	// The page only has inline styles and a JSON data block, which never runs
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors "+s.frameAncestors())
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/transition"
+)
+
+// sseHeartbeatInterval is how often an idle event stream sends a comment so
+// proxies do not close it
+const sseHeartbeatInterval = 15 * time.Second
+
+// sseRetry is the reconnect delay in milliseconds suggested to EventSource clients
+const sseRetry = 5000
+
+// eventStream writes Server-Sent Events. Only the subscription confirmation and
+// transitions events carry an ID: the ID of the latest transition observed when the
+// event was sent. EventSource keeps the last ID across events without one, so a
+// reconnecting client's Last-Event-ID tells the server which transitions it missed.
+type eventStream struct {
+	c *gin.Context
+}
+
+// send writes one event without an ID; data is a JSON message carrying its own type like on /ws
+func (e *eventStream) send(data interface{}) error {
+	return e.write("", data)
+}
+
+// sendWithID writes one event that moves the client's Last-Event-ID to id
+func (e *eventStream) sendWithID(id int64, data interface{}) error {
+	return e.write(fmt.Sprintf("id: %d\n", id), data)
+}
+
+// write writes one event with the given ID line
+func (e *eventStream) write(idLine string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(e.c.Writer, "%sdata: %s\n\n", idLine, payload); err != nil {
+		return err
+	}
+	e.c.Writer.Flush()
+	return nil
+}
+
+// comment writes an SSE comment, which EventSource clients ignore
+func (e *eventStream) comment(text string) error {
+	if _, err := fmt.Fprintf(e.c.Writer, ": %s\n\n", text); err != nil {
+		return err
+	}
+	e.c.Writer.Flush()
+	return nil
+}
+
+// subscriptionFromQuery builds a subscribe message from query parameters, using
+// the same names as the dashboard URL: namespace, selector, hpa, interval and events
+func subscriptionFromQuery(c *gin.Context) (ClientMessage, error) {
+	request := ClientMessage{Type: messageTypeSubscribe}
+	request.Namespaces = queryList(c.Request.URL.Query(), "namespace")
+	request.LabelSelector = c.Query("selector")
+	request.HPAs = queryList(c.Request.URL.Query(), "hpa")
+	if interval := c.Query("interval"); interval != "" {
+		seconds, err := strconv.Atoi(interval)
+		if err != nil {
+			return request, fmt.Errorf("invalid interval %q: must be a number of seconds", interval)
+		}
+		request.IntervalSeconds = seconds
+	}
+	if events := c.Query("events"); events != "" {
+		includeEvents, err := strconv.ParseBool(events)
+		if err != nil {
+			return request, fmt.Errorf("invalid events %q: must be true or false", events)
+		}
+		request.IncludeEvents = &includeEvents
+	}
+	return request, nil
+}
+
+// queryList returns a repeatable, comma-separated query parameter
+func queryList(query url.Values, name string) []string {
+	var values []string
+	for _, value := range query[name] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+// handleStream serves the v2 snapshot and patch feed, transitions and config
+// notices as Server-Sent Events for clients that cannot use WebSockets
+func (s *Server) handleStream(c *gin.Context) {
+	log := logger.GetLogger()
+	clientIP := c.ClientIP()
+
+	cfg := s.getConfig()
+	request, err := subscriptionFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sub, err := newSubscription(request,
+		time.Duration(cfg.WebSocketInterval)*time.Second,
+		time.Duration(cfg.WebSocketMinInterval)*time.Second,
+		time.Duration(cfg.WebSocketMaxInterval)*time.Second)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resumeAfter := int64(-1)
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		if id, err := strconv.ParseInt(lastEventID, 10, 64); err == nil && id >= 0 {
+			resumeAfter = id
+		}
+	}
+
+	s.connections.Add(1)
+	defer s.connections.Done()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	events := &eventStream{c: c}
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetry); err != nil {
+		return
+	}
+
+	log.WithFields(logger.Fields{
+		"client_ip": clientIP,
+		"resume":    resumeAfter >= 0,
+	}).Info("Event stream established")
+
+	ctx := c.Request.Context()
+	messages := s.register()
+	defer s.unregister(messages)
+
+	// Every connection starts with the effective filter and a snapshot; a resuming
+	// client also gets the transitions it missed, since those are not part of the state.
+	// Transitions observed after registering are broadcast, so the confirmation's ID
+	// is where a later resume continues from.
+	visible := make(map[string]bool)
+	stream := newDeltaStream()
+	var missed []transition.ScaleTransition
+	var lastID int64
+	if s.tracker != nil {
+		lastID = s.tracker.LastID()
+		if resumeAfter >= 0 {
+			missed = s.tracker.After(resumeAfter)
+		}
+	}
+	if err := events.sendWithID(lastID, sub.confirmation); err != nil {
+		return
+	}
+	if !s.sendStreamSnapshot(ctx, events, stream, sub, visible, clientIP) {
+		return
+	}
+	if len(missed) > 0 {
+		if message, ok := filterBroadcast(TransitionsMessage{Type: "transitions", Transitions: missed}, visible); ok {
+			if err := events.send(message); err != nil {
+				return
+			}
+		}
+	}
+
+	ticker := time.NewTicker(sub.interval)
+	defer ticker.Stop()
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.WithField("client_ip", clientIP).Info("Event stream closed")
+			return
+		case <-s.shutdown:
+			// EventSource reconnects after the retry delay, reaching another replica or the restarted server
+			return
+		case message := <-messages:
+			s.applyReload(message, sub, ticker)
+			// The ID covers every broadcast transition, including ones filtered out below
+			id := int64(-1)
+			if transitions, ok := message.(TransitionsMessage); ok && len(transitions.Transitions) > 0 {
+				id = transitions.Transitions[len(transitions.Transitions)-1].ID
+			}
+			if sub.filtered() {
+				var ok bool
+				if message, ok = filterBroadcast(message, visible); !ok {
+					continue
+				}
+			}
+			var err error
+			if id >= 0 {
+				err = events.sendWithID(id, message)
+			} else {
+				err = events.send(message)
+			}
+			if err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := events.comment("heartbeat"); err != nil {
+				return
+			}
+		case <-ticker.C:
+			hpaStatuses, err := s.hpaMonitor.ListHPAStatus(ctx, sub.statusOptions())
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.WithField("client_ip", clientIP).WithError(err).Error("Error getting HPA status for event stream")
+				continue
+			}
+			hpaStatuses = sub.apply(hpaStatuses)
+			setVisible(visible, hpaStatuses)
+			if err := events.send(stream.patch(hpaStatuses)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendStreamSnapshot writes a full snapshot event; it returns false when the stream is unusable
+func (s *Server) sendStreamSnapshot(ctx context.Context, events *eventStream, stream *deltaStream, sub *subscription, visible map[string]bool, clientIP string) bool {
+	log := logger.GetLogger()
+
+	hpaStatuses, err := s.hpaMonitor.ListHPAStatus(ctx, sub.statusOptions())
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		log.WithField("client_ip", clientIP).WithError(err).Error("Error getting HPA status for event stream snapshot")
+		hpaStatuses = nil
+	}
+	hpaStatuses = sub.apply(hpaStatuses)
+	setVisible(visible, hpaStatuses)
+
+	return events.send(stream.snapshot(hpaStatuses)) == nil
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"hpa-monitor/pkg/config"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/transition"
+)
+
+func TestStreamResumesAfterLastEventID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := transition.NewTracker("", time.Hour)
+	status := func(current, desired int32, events ...monitor.Event) []monitor.HPAStatus {
+		return []monitor.HPAStatus{{Namespace: "shop", Name: "checkout", CurrentReplicas: current, DesiredReplicas: desired, Events: events}}
+	}
+	start := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	tracker.Observe(status(3, 3), start)
+	seen := tracker.Observe(status(4, 4), start.Add(time.Minute))
+	// Observed after the client's last event, though the event happened an hour earlier
+	newReplicas := int32(8)
+	tracker.Observe(status(4, 4, monitor.Event{
+		Reason:        "SuccessfulRescale",
+		Message:       "New size: 8",
+		LastTimestamp: "2024-01-01T00:00:00Z",
+		NewReplicas:   &newReplicas,
+	}), start.Add(2*time.Minute))
+
+	// Missed transitions are filtered to the HPAs in the snapshot
+	clientset := fake.NewSimpleClientset(
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}},
+	)
+	s := NewServer(monitor.NewHPAMonitor(clientset), nil, nil, config.Default())
+	s.SetTransitions(tracker)
+	r := gin.New()
+	s.setupAPIRoutes(r)
+	httpServer := httptest.NewServer(r)
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/api/v1/stream", nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Last-Event-ID", strconv.FormatInt(seen[len(seen)-1].ID, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("opening stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var confirmationID string
+	scanner := bufio.NewScanner(resp.Body)
+	id := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "id: "); ok {
+			id = value
+			continue
+		}
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		var message struct {
+			Type        string                       `json:"type"`
+			Transitions []transition.ScaleTransition `json:"transitions"`
+		}
+		if err := json.Unmarshal([]byte(data), &message); err != nil {
+			t.Fatalf("decoding %s: %v", data, err)
+		}
+		switch message.Type {
+		case messageTypeSubscribed:
+			confirmationID = id
+		case "transitions":
+			if len(message.Transitions) != 1 || message.Transitions[0].Source != transition.SourceEvent {
+				t.Fatalf("missed transitions %+v, want only the event observed after Last-Event-ID", message.Transitions)
+			}
+			if want := strconv.FormatInt(tracker.LastID(), 10); confirmationID != want {
+				t.Errorf("confirmation ID %q, want the latest transition ID %s", confirmationID, want)
+			}
+			return
+		}
+		id = ""
+	}
+	t.Fatalf("stream ended without the missed transitions: %v", scanner.Err())
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/monitor"
+	"hpa-monitor/pkg/transition"
+)
+
+// Message types for WebSocket subscriptions
+const (
+	messageTypeSubscribe  = "subscribe"
+	messageTypeSubscribed = "subscribed"
+	messageTypeError      = "error"
+)
+
+// SubscribedMessage confirms the filter a connection now receives
+type SubscribedMessage struct {
+	Type            string   `json:"type"`
+	Namespaces      []string `json:"namespaces,omitempty"`
+	LabelSelector   string   `json:"labelSelector,omitempty"`
+	HPAs            []string `json:"hpas,omitempty"`
+	IntervalSeconds int      `json:"intervalSeconds"`
+	IncludeEvents   bool     `json:"includeEvents"`
+}
+
+// ErrorMessage reports an invalid client message; the connection stays open
+type ErrorMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// subscription is the per-connection filter set by a subscribe message
+type subscription struct {
+	namespaces map[string]bool
+	selector   labels.Selector
+	hpas       map[string]bool
+	interval   time.Duration
+	// customInterval is set when the client chose the interval, so reloads leave it alone
+	customInterval bool
+	includeEvents  bool
+	confirmation   SubscribedMessage
+}
+
+// defaultSubscription matches every HPA at the server's interval
+func defaultSubscription(interval time.Duration) *subscription {
+	return &subscription{
+		selector:      labels.Everything(),
+		interval:      interval,
+		includeEvents: true,
+	}
+}
+
+// newSubscription validates a subscribe message; the interval must lie within [minInterval, maxInterval]
+// and defaults to defaultInterval
+func newSubscription(request ClientMessage, defaultInterval, minInterval, maxInterval time.Duration) (*subscription, error) {
+	sub := defaultSubscription(defaultInterval)
+
+	if len(request.Namespaces) > 0 {
+		sub.namespaces = make(map[string]bool, len(request.Namespaces))
+		for _, namespace := range request.Namespaces {
+			sub.namespaces[namespace] = true
+		}
+	}
+	if request.LabelSelector != "" {
+		selector, err := labels.Parse(request.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %v", err)
+		}
+		sub.selector = selector
+	}
+	if len(request.HPAs) > 0 {
+		sub.hpas = make(map[string]bool, len(request.HPAs))
+		for _, key := range request.HPAs {
+			sub.hpas[key] = true
+		}
+	}
+	if request.IntervalSeconds != 0 {
+		interval := time.Duration(request.IntervalSeconds) * time.Second
+		if interval < minInterval || interval > maxInterval {
+			return nil, fmt.Errorf("intervalSeconds must be between %d and %d, got %d",
+				int(minInterval.Seconds()), int(maxInterval.Seconds()), request.IntervalSeconds)
+		}
+		sub.interval = interval
+		sub.customInterval = true
+	}
+	if request.IncludeEvents != nil {
+		sub.includeEvents = *request.IncludeEvents
+	}
+
+	sub.confirmation = SubscribedMessage{
+		Type:            messageTypeSubscribed,
+		Namespaces:      request.Namespaces,
+		LabelSelector:   sub.selector.String(),
+		HPAs:            request.HPAs,
+		IntervalSeconds: int(sub.interval.Seconds()),
+		IncludeEvents:   sub.includeEvents,
+	}
+	return sub, nil
+}
+
+// reload applies a reloaded server interval unless the client chose its own, and reports
+// whether the interval changed
+func (sub *subscription) reload(interval time.Duration) bool {
+	if sub.customInterval || interval == sub.interval {
+		return false
+	}
+	sub.interval = interval
+	return true
+}
+
+// matches reports whether an HPA passes the filter
+func (sub *subscription) matches(status monitor.HPAStatus) bool {
+	if sub.namespaces != nil && !sub.namespaces[status.Namespace] {
+		return false
+	}
+	if sub.hpas != nil && !sub.hpas[history.Key(status.Namespace, status.Name)] {
+		return false
+	}
+	return sub.selector.Matches(labels.Set(status.Labels))
+}
+
+// filtered reports whether the subscription hides any HPAs
+func (sub *subscription) filtered() bool {
+	return sub.namespaces != nil || sub.hpas != nil || !sub.selector.Empty()
+}
+
+// statusOptions limits the cluster fetch to the subscribed namespaces, listing events only when requested
+func (sub *subscription) statusOptions() monitor.StatusOptions {
+	opts := monitor.StatusOptions{SkipEvents: !sub.includeEvents}
+	for namespace := range sub.namespaces {
+		opts.Namespaces = append(opts.Namespaces, namespace)
+	}
+	sort.Strings(opts.Namespaces)
+	return opts
+}
+
+// apply returns the HPAs passing the filter, without events unless requested
+func (sub *subscription) apply(statuses []monitor.HPAStatus) []monitor.HPAStatus {
+	filtered := make([]monitor.HPAStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if !sub.matches(status) {
+			continue
+		}
+		if !sub.includeEvents {
+			status.Events = nil
+		}
+		filtered = append(filtered, status)
+	}
+	return filtered
+}
+
+// setVisible replaces visible with the keys of the HPAs sent to the client
+func setVisible(visible map[string]bool, statuses []monitor.HPAStatus) {
+	for key := range visible {
+		delete(visible, key)
+	}
+	for _, status := range statuses {
+		visible[history.Key(status.Namespace, status.Name)] = true
+	}
+}
+
+// filterBroadcast drops transitions for HPAs outside visible, the HPAs last sent to the client
+func filterBroadcast(message interface{}, visible map[string]bool) (interface{}, bool) {
+	transitions, ok := message.(TransitionsMessage)
+	if !ok {
+		return message, true
+	}
+
+	var kept []transition.ScaleTransition
+	for _, t := range transitions.Transitions {
+		if visible[history.Key(t.Namespace, t.Name)] {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, false
+	}
+	transitions.Transitions = kept
+	return transitions, true
+}
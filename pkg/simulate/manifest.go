@@ -0,0 +1,64 @@
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseManifest decodes an autoscaling/v2 HPA manifest in YAML or JSON
+func ParseManifest(data []byte) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	if err := yaml.Unmarshal(data, &hpa); err != nil {
+		return nil, fmt.Errorf("failed to parse HPA manifest: %v", err)
+	}
+	if hpa.Kind != "" && hpa.Kind != "HorizontalPodAutoscaler" {
+		return nil, fmt.Errorf("manifest kind must be HorizontalPodAutoscaler, got %q", hpa.Kind)
+	}
+	if hpa.APIVersion != "" && hpa.APIVersion != "autoscaling/v2" {
+		return nil, fmt.Errorf("manifest apiVersion must be autoscaling/v2, got %q", hpa.APIVersion)
+	}
+	return &hpa, nil
+}
+
+// ParseSeries decodes a metric series from a JSON array of points or "offset,value" CSV lines
+func ParseSeries(data []byte) ([]Point, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		var points []Point
+		if err := json.Unmarshal([]byte(trimmed), &points); err != nil {
+			return nil, fmt.Errorf("failed to parse series JSON: %v", err)
+		}
+		return points, nil
+	}
+
+	var points []Point
+	for i, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("series line %d: expected offset,value", i+1)
+		}
+		offset, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			// Allow a header row
+			if i == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("series line %d: invalid offset: %v", i+1, err)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("series line %d: invalid value: %v", i+1, err)
+		}
+		points = append(points, Point{Offset: offset, Value: value})
+	}
+	return points, nil
+}
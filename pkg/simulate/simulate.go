@@ -0,0 +1,538 @@
+package simulate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+
+	"hpa-monitor/pkg/monitor"
+)
+
+const (
+	// DefaultStepSeconds matches the HPA controller's default sync period
+	DefaultStepSeconds = 15
+	// DefaultTolerance matches the controller's --horizontal-pod-autoscaler-tolerance default
+	DefaultTolerance = 0.1
+	// defaultScaleDownStabilizationSeconds is the default scale down stabilization window
+	defaultScaleDownStabilizationSeconds = 300
+)
+
+// Limits on a simulation run, so a request cannot make Run loop or allocate without bound
+const (
+	// MaxSteps is the most sync periods a run may simulate
+	MaxSteps = 10000
+	// MaxStepSeconds is the longest sync period
+	MaxStepSeconds = 3600
+	// MaxOffsetSeconds is the latest offset a series point may have, 30 days
+	MaxOffsetSeconds = 30 * 24 * 3600
+)
+
+// Point is one value of the hypothetical metric time series
+type Point struct {
+	Offset int     `json:"offset"` // seconds since the start of the simulation
+	Value  float64 `json:"value"`  // utilization percent or per-pod average, in the unit of the HPA target
+}
+
+// Input describes a simulation run
+type Input struct {
+	HPA             *autoscalingv2.HorizontalPodAutoscaler
+	Series          []Point
+	InitialReplicas int32
+	StepSeconds     int
+	// Tolerance applies in both directions unless spec.behavior sets one; zero is no tolerance
+	Tolerance float64
+}
+
+// Step is the controller decision at one sync period
+type Step struct {
+	Offset             int     `json:"offset"`
+	Metric             float64 `json:"metric"`
+	Ratio              float64 `json:"ratio"`
+	CurrentReplicas    int32   `json:"currentReplicas"`
+	ProposedReplicas   int32   `json:"proposedReplicas"`
+	StabilizedReplicas int32   `json:"stabilizedReplicas"`
+	DesiredReplicas    int32   `json:"desiredReplicas"`
+	Reason             string  `json:"reason"`
+}
+
+// Result is the replica trajectory produced by a simulation
+type Result struct {
+	Namespace  string  `json:"namespace"`
+	Name       string  `json:"name"`
+	MetricName string  `json:"metricName"`
+	Target     float64 `json:"target"`
+	Tolerance  float64 `json:"tolerance"`
+	// Per-direction tolerances, from spec.behavior when set and Tolerance otherwise
+	ScaleUpTolerance   float64 `json:"scaleUpTolerance"`
+	ScaleDownTolerance float64 `json:"scaleDownTolerance"`
+	StepSeconds        int     `json:"stepSeconds"`
+	Steps              []Step  `json:"steps"`
+}
+
+// timestampedRecommendation is a raw replica proposal kept for stabilization windows
+type timestampedRecommendation struct {
+	recommendation int32
+	offset         int
+}
+
+// scaleEvent is a replica change kept for rate limiting policies
+type scaleEvent struct {
+	change int32
+	offset int
+}
+
+// simulator holds the controller state carried between sync periods
+type simulator struct {
+	scaleUp         autoscalingv2.HPAScalingRules
+	scaleDown       autoscalingv2.HPAScalingRules
+	upTolerance     float64
+	downTolerance   float64
+	minReplicas     int32
+	maxReplicas     int32
+	recommendations []timestampedRecommendation
+	scaleUpEvents   []scaleEvent
+	scaleDownEvents []scaleEvent
+	offset          int
+}
+
+// Run replays the HPA controller algorithm over the metric series
+func Run(input Input) (*Result, error) {
+	hpa := input.HPA
+	if hpa == nil {
+		return nil, fmt.Errorf("hpa is required")
+	}
+	if len(input.Series) == 0 {
+		return nil, fmt.Errorf("series must contain at least one point")
+	}
+	if len(input.Series) > MaxSteps {
+		return nil, fmt.Errorf("series must contain at most %d points, got %d", MaxSteps, len(input.Series))
+	}
+	if hpa.Spec.MaxReplicas < 1 {
+		return nil, fmt.Errorf("spec.maxReplicas must be at least 1")
+	}
+
+	stepSeconds := input.StepSeconds
+	if stepSeconds <= 0 {
+		stepSeconds = DefaultStepSeconds
+	}
+	if stepSeconds > MaxStepSeconds {
+		return nil, fmt.Errorf("stepSeconds must be at most %d, got %d", MaxStepSeconds, stepSeconds)
+	}
+
+	controller, err := NewController(hpa, input.Tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]Point, len(input.Series))
+	copy(series, input.Series)
+	sort.Slice(series, func(i, j int) bool { return series[i].Offset < series[j].Offset })
+
+	// Bounded offsets and steps keep offset += stepSeconds from overflowing below
+	start, end := series[0].Offset, series[len(series)-1].Offset
+	if start < 0 || end > MaxOffsetSeconds {
+		return nil, fmt.Errorf("series offsets must be between 0 and %d seconds", MaxOffsetSeconds)
+	}
+	if steps := (end-start)/stepSeconds + 1; steps > MaxSteps {
+		return nil, fmt.Errorf("series spans %d steps of %ds, at most %d are allowed; use a longer step", steps, stepSeconds, MaxSteps)
+	}
+
+	current := input.InitialReplicas
+	if current <= 0 {
+		current = hpa.Status.CurrentReplicas
+	}
+	if current <= 0 {
+		current = controller.sim.minReplicas
+	}
+
+	result := &Result{
+		Namespace:          hpa.Namespace,
+		Name:               hpa.Name,
+		MetricName:         controller.metricName,
+		Target:             controller.target,
+		Tolerance:          controller.tolerance,
+		ScaleUpTolerance:   controller.sim.upTolerance,
+		ScaleDownTolerance: controller.sim.downTolerance,
+		StepSeconds:        stepSeconds,
+	}
+
+	idx := 0
+	for offset := start; offset <= end; offset += stepSeconds {
+		for idx+1 < len(series) && series[idx+1].Offset <= offset {
+			idx++
+		}
+		step := controller.Sync(offset, current, series[idx].Value)
+		result.Steps = append(result.Steps, step)
+		current = step.DesiredReplicas
+	}
+
+	return result, nil
+}
+
+// Controller makes step-by-step scaling decisions for callers that produce metrics incrementally
+type Controller struct {
+	sim        *simulator
+	metricName string
+	target     float64
+	tolerance  float64
+}
+
+// NewController creates a controller for the HPA with a tolerance between 0.0 and 1.0.
+// Tolerances set in spec.behavior take precedence in their direction.
+func NewController(hpa *autoscalingv2.HorizontalPodAutoscaler, tolerance float64) (*Controller, error) {
+	metricName, target, err := primaryTarget(hpa)
+	if err != nil {
+		return nil, err
+	}
+	if tolerance < 0 || tolerance > 1 {
+		return nil, fmt.Errorf("tolerance must be between 0.0 and 1.0, got %g", tolerance)
+	}
+
+	return &Controller{
+		sim:        newSimulator(hpa, tolerance),
+		metricName: metricName,
+		target:     target,
+		tolerance:  tolerance,
+	}, nil
+}
+
+// Sync performs one reconciliation at offset seconds with the observed metric value
+func (c *Controller) Sync(offset int, current int32, metric float64) Step {
+	return c.sim.sync(offset, current, metric, c.target)
+}
+
+// newSimulator builds the controller state with behavior defaults applied
+func newSimulator(hpa *autoscalingv2.HorizontalPodAutoscaler, tolerance float64) *simulator {
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	var scaleUp, scaleDown *autoscalingv2.HPAScalingRules
+	if hpa.Spec.Behavior != nil {
+		scaleUp = hpa.Spec.Behavior.ScaleUp
+		scaleDown = hpa.Spec.Behavior.ScaleDown
+	}
+
+	upTolerance, downTolerance := tolerance, tolerance
+	if value, ok := monitor.BehaviorTolerance(scaleUp); ok {
+		upTolerance = value
+	}
+	if value, ok := monitor.BehaviorTolerance(scaleDown); ok {
+		downTolerance = value
+	}
+
+	return &simulator{
+		scaleUp:       scaleUpRules(scaleUp),
+		scaleDown:     scaleDownRules(scaleDown),
+		upTolerance:   upTolerance,
+		downTolerance: downTolerance,
+		minReplicas:   minReplicas,
+		maxReplicas:   hpa.Spec.MaxReplicas,
+	}
+}
+
+// sync performs one controller reconciliation at the given offset
+func (s *simulator) sync(offset int, current int32, metric, target float64) Step {
+	s.offset = offset
+	step := Step{
+		Offset:          offset,
+		Metric:          metric,
+		CurrentReplicas: current,
+	}
+
+	// Out-of-range replicas are corrected before metrics are considered
+	if current > s.maxReplicas {
+		step.ProposedReplicas, step.StabilizedReplicas, step.DesiredReplicas = s.maxReplicas, s.maxReplicas, s.maxReplicas
+		step.Reason = "CurrentAboveMax"
+		s.storeScaleEvent(offset, current, step.DesiredReplicas)
+		return step
+	}
+	if current < s.minReplicas {
+		step.ProposedReplicas, step.StabilizedReplicas, step.DesiredReplicas = s.minReplicas, s.minReplicas, s.minReplicas
+		step.Reason = "CurrentBelowMin"
+		s.storeScaleEvent(offset, current, step.DesiredReplicas)
+		return step
+	}
+
+	step.Ratio = metric / target
+	step.ProposedReplicas = current
+	if step.Ratio > 1.0+s.upTolerance || step.Ratio < 1.0-s.downTolerance {
+		step.ProposedReplicas = int32(math.Ceil(step.Ratio * float64(current)))
+	}
+
+	step.StabilizedReplicas = s.stabilize(offset, current, step.ProposedReplicas)
+	step.DesiredReplicas, step.Reason = s.limitRate(current, step.StabilizedReplicas)
+	if step.StabilizedReplicas != step.ProposedReplicas && step.Reason == "DesiredWithinRange" {
+		step.Reason = "Stabilized"
+	}
+
+	s.storeScaleEvent(offset, current, step.DesiredReplicas)
+	return step
+}
+
+// stabilize applies the scale up and scale down stabilization windows
+func (s *simulator) stabilize(offset int, current, proposed int32) int32 {
+	upRecommendation := proposed
+	downRecommendation := proposed
+	upCutoff := offset - int(*s.scaleUp.StabilizationWindowSeconds)
+	downCutoff := offset - int(*s.scaleDown.StabilizationWindowSeconds)
+
+	retained := s.recommendations[:0]
+	longest := max(int(*s.scaleUp.StabilizationWindowSeconds), int(*s.scaleDown.StabilizationWindowSeconds))
+	for _, rec := range s.recommendations {
+		if rec.offset > upCutoff {
+			upRecommendation = min(upRecommendation, rec.recommendation)
+		}
+		if rec.offset > downCutoff {
+			downRecommendation = max(downRecommendation, rec.recommendation)
+		}
+		if rec.offset > offset-longest {
+			retained = append(retained, rec)
+		}
+	}
+	s.recommendations = append(retained, timestampedRecommendation{recommendation: proposed, offset: offset})
+
+	recommendation := current
+	if recommendation < upRecommendation {
+		recommendation = upRecommendation
+	}
+	if recommendation > downRecommendation {
+		recommendation = downRecommendation
+	}
+	return recommendation
+}
+
+// limitRate applies scaling policies and min/max clamping
+func (s *simulator) limitRate(current, desired int32) (int32, string) {
+	if desired > current {
+		scaleUpLimit := s.scaleUpLimit(current)
+		if scaleUpLimit < current {
+			scaleUpLimit = current
+		}
+		maximumAllowed, reason := s.maxReplicas, "TooManyReplicas"
+		if maximumAllowed > scaleUpLimit {
+			maximumAllowed, reason = scaleUpLimit, "ScaleUpLimit"
+		}
+		if desired > maximumAllowed {
+			return maximumAllowed, reason
+		}
+	} else if desired < current {
+		scaleDownLimit := s.scaleDownLimit(current)
+		if scaleDownLimit > current {
+			scaleDownLimit = current
+		}
+		minimumAllowed, reason := s.minReplicas, "TooFewReplicas"
+		if minimumAllowed < scaleDownLimit {
+			minimumAllowed, reason = scaleDownLimit, "ScaleDownLimit"
+		}
+		if desired < minimumAllowed {
+			return minimumAllowed, reason
+		}
+	}
+	return desired, "DesiredWithinRange"
+}
+
+// scaleUpLimit returns the highest replica count the scale up policies allow
+func (s *simulator) scaleUpLimit(current int32) int32 {
+	rules := s.scaleUp
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return current
+	}
+
+	result, selectFn := int32(math.MinInt32), maxInt32
+	if *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect {
+		result, selectFn = math.MaxInt32, minInt32
+	}
+
+	for _, policy := range rules.Policies {
+		added := changeInPeriod(s.scaleUpEvents, s.offset, policy.PeriodSeconds)
+		deleted := changeInPeriod(s.scaleDownEvents, s.offset, policy.PeriodSeconds)
+		periodStart := current - added + deleted
+
+		var proposed int32
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			proposed = periodStart + policy.Value
+		} else {
+			proposed = int32(math.Ceil(float64(periodStart) * (1 + float64(policy.Value)/100)))
+		}
+		result = selectFn(result, proposed)
+	}
+	return result
+}
+
+// scaleDownLimit returns the lowest replica count the scale down policies allow
+func (s *simulator) scaleDownLimit(current int32) int32 {
+	rules := s.scaleDown
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return current
+	}
+
+	result, selectFn := int32(math.MaxInt32), minInt32
+	if *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect {
+		result, selectFn = math.MinInt32, maxInt32
+	}
+
+	for _, policy := range rules.Policies {
+		added := changeInPeriod(s.scaleUpEvents, s.offset, policy.PeriodSeconds)
+		deleted := changeInPeriod(s.scaleDownEvents, s.offset, policy.PeriodSeconds)
+		periodStart := current - added + deleted
+
+		var proposed int32
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			proposed = periodStart - policy.Value
+		} else {
+			proposed = int32(float64(periodStart) * (1 - float64(policy.Value)/100))
+		}
+		result = selectFn(result, proposed)
+	}
+	return result
+}
+
+// storeScaleEvent records a replica change for future rate limiting
+func (s *simulator) storeScaleEvent(offset int, previous, desired int32) {
+	s.scaleUpEvents = expireEvents(s.scaleUpEvents, offset, longestPeriod(s.scaleUp))
+	s.scaleDownEvents = expireEvents(s.scaleDownEvents, offset, longestPeriod(s.scaleDown))
+
+	if desired > previous {
+		s.scaleUpEvents = append(s.scaleUpEvents, scaleEvent{change: desired - previous, offset: offset})
+	} else if desired < previous {
+		s.scaleDownEvents = append(s.scaleDownEvents, scaleEvent{change: previous - desired, offset: offset})
+	}
+}
+
+// changeInPeriod sums replica changes within periodSeconds before offset
+func changeInPeriod(events []scaleEvent, offset int, periodSeconds int32) int32 {
+	var total int32
+	for _, event := range events {
+		if event.offset > offset-int(periodSeconds) {
+			total += event.change
+		}
+	}
+	return total
+}
+
+// expireEvents drops events older than the longest policy period
+func expireEvents(events []scaleEvent, offset int, period int32) []scaleEvent {
+	retained := events[:0]
+	for _, event := range events {
+		if event.offset > offset-int(period) {
+			retained = append(retained, event)
+		}
+	}
+	return retained
+}
+
+// longestPeriod returns the longest policy period in the rules
+func longestPeriod(rules autoscalingv2.HPAScalingRules) int32 {
+	var longest int32
+	for _, policy := range rules.Policies {
+		longest = max(longest, policy.PeriodSeconds)
+	}
+	return longest
+}
+
+// primaryTarget returns the name and numeric target of the first metric
+func primaryTarget(hpa *autoscalingv2.HorizontalPodAutoscaler) (string, float64, error) {
+	if len(hpa.Spec.Metrics) == 0 {
+		return "", 0, fmt.Errorf("spec.metrics must contain at least one metric")
+	}
+
+	metric := hpa.Spec.Metrics[0]
+	var name string
+	var target autoscalingv2.MetricTarget
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		name, target = string(metric.Resource.Name), metric.Resource.Target
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		name, target = string(metric.ContainerResource.Name), metric.ContainerResource.Target
+	case autoscalingv2.ExternalMetricSourceType:
+		name, target = metric.External.Metric.Name, metric.External.Target
+	case autoscalingv2.ObjectMetricSourceType:
+		name, target = metric.Object.Metric.Name, metric.Object.Target
+	case autoscalingv2.PodsMetricSourceType:
+		name, target = metric.Pods.Metric.Name, metric.Pods.Target
+	default:
+		return "", 0, fmt.Errorf("unsupported metric type %q", metric.Type)
+	}
+
+	var value float64
+	switch {
+	case target.AverageUtilization != nil:
+		value = float64(*target.AverageUtilization)
+	case target.AverageValue != nil:
+		value = target.AverageValue.AsApproximateFloat64()
+	case target.Value != nil:
+		value = target.Value.AsApproximateFloat64()
+	}
+	if value <= 0 {
+		return "", 0, fmt.Errorf("metric %q has no positive target", name)
+	}
+	return name, value, nil
+}
+
+// scaleUpRules fills scale up defaults the same way the API server does
+func scaleUpRules(rules *autoscalingv2.HPAScalingRules) autoscalingv2.HPAScalingRules {
+	result := autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: int32Ptr(0),
+		SelectPolicy:               selectPtr(autoscalingv2.MaxChangePolicySelect),
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+	return mergeRules(result, rules)
+}
+
+// scaleDownRules fills scale down defaults the same way the API server does
+func scaleDownRules(rules *autoscalingv2.HPAScalingRules) autoscalingv2.HPAScalingRules {
+	result := autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: int32Ptr(defaultScaleDownStabilizationSeconds),
+		SelectPolicy:               selectPtr(autoscalingv2.MaxChangePolicySelect),
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+	return mergeRules(result, rules)
+}
+
+// mergeRules overrides defaults with the fields set on the HPA
+func mergeRules(defaults autoscalingv2.HPAScalingRules, rules *autoscalingv2.HPAScalingRules) autoscalingv2.HPAScalingRules {
+	if rules == nil {
+		return defaults
+	}
+	if rules.StabilizationWindowSeconds != nil {
+		defaults.StabilizationWindowSeconds = rules.StabilizationWindowSeconds
+	}
+	if rules.SelectPolicy != nil {
+		defaults.SelectPolicy = rules.SelectPolicy
+	}
+	if len(rules.Policies) > 0 {
+		defaults.Policies = rules.Policies
+	}
+	return defaults
+}
+
+// FormatOffset renders a step offset as a duration for display
+func FormatOffset(offset int) string {
+	return (time.Duration(offset) * time.Second).String()
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func selectPtr(v autoscalingv2.ScalingPolicySelect) *autoscalingv2.ScalingPolicySelect {
+	return &v
+}
+
+func minInt32(a, b int32) int32 {
+	return min(a, b)
+}
+
+func maxInt32(a, b int32) int32 {
+	return max(a, b)
+}
@@ -0,0 +1,161 @@
+package simulate
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cpuHPA targets 50% CPU between 1 and maxReplicas with the given behavior
+func cpuHPA(maxReplicas int32, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) *autoscalingv2.HorizontalPodAutoscaler {
+	utilization := int32(50)
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MinReplicas: int32Ptr(1),
+			MaxReplicas: maxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &utilization},
+				},
+			}},
+			Behavior: behavior,
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	// Steady at target for a minute, then the given value
+	stepTo := func(value float64) []Point {
+		return []Point{{Offset: 0, Value: 50}, {Offset: 60, Value: value}, {Offset: 600, Value: value}}
+	}
+	noDownWindow := &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: int32Ptr(0)}
+
+	tests := []struct {
+		name      string
+		hpa       *autoscalingv2.HorizontalPodAutoscaler
+		series    []Point
+		tolerance float64
+		// want maps offsets to the desired replicas and reason at that step
+		want map[int]Step
+	}{
+		{
+			name:      "scale down is held by the default 300s window",
+			hpa:       cpuHPA(20, nil),
+			series:    stepTo(25),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60:  {ProposedReplicas: 5, DesiredReplicas: 10, Reason: "Stabilized"},
+				330: {ProposedReplicas: 5, DesiredReplicas: 10, Reason: "Stabilized"},
+				// The last recommendation of 10 was made at 45s
+				345: {ProposedReplicas: 5, DesiredReplicas: 5, Reason: "DesiredWithinRange"},
+			},
+		},
+		{
+			name:      "default scale up selects the larger of 4 pods and 100 percent",
+			hpa:       cpuHPA(100, nil),
+			series:    stepTo(200),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60: {ProposedReplicas: 40, DesiredReplicas: 20, Reason: "ScaleUpLimit"},
+				75: {ProposedReplicas: 80, DesiredReplicas: 40, Reason: "ScaleUpLimit"},
+			},
+		},
+		{
+			name: "selectPolicy Min selects the smaller of 4 pods and 100 percent",
+			hpa: cpuHPA(100, &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{SelectPolicy: selectPtr(autoscalingv2.MinChangePolicySelect)},
+			}),
+			series:    stepTo(200),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60: {ProposedReplicas: 40, DesiredReplicas: 14, Reason: "ScaleUpLimit"},
+				75: {ProposedReplicas: 56, DesiredReplicas: 18, Reason: "ScaleUpLimit"},
+			},
+		},
+		{
+			name: "pods policy limits the change over its whole period",
+			hpa: cpuHPA(100, &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{Policies: []autoscalingv2.HPAScalingPolicy{
+					{Type: autoscalingv2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60},
+				}},
+			}),
+			series:    stepTo(200),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60:  {DesiredReplicas: 12, Reason: "ScaleUpLimit"},
+				105: {DesiredReplicas: 12, Reason: "ScaleUpLimit"},
+				120: {DesiredReplicas: 14, Reason: "ScaleUpLimit"},
+			},
+		},
+		{
+			name: "disabled scale down keeps the replicas",
+			hpa: cpuHPA(20, &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: int32Ptr(0),
+					SelectPolicy:               selectPtr(autoscalingv2.DisabledPolicySelect),
+				},
+			}),
+			series:    stepTo(10),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60:  {ProposedReplicas: 2, DesiredReplicas: 10, Reason: "ScaleDownLimit"},
+				600: {ProposedReplicas: 2, DesiredReplicas: 10, Reason: "ScaleDownLimit"},
+			},
+		},
+		{
+			name:      "maxReplicas caps the scale up",
+			hpa:       cpuHPA(12, nil),
+			series:    stepTo(200),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60: {ProposedReplicas: 40, DesiredReplicas: 12, Reason: "TooManyReplicas"},
+			},
+		},
+		{
+			name:      "tolerance suppresses an 8 percent deviation",
+			hpa:       cpuHPA(20, &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleDown: noDownWindow}),
+			series:    stepTo(54),
+			tolerance: DefaultTolerance,
+			want: map[int]Step{
+				60: {ProposedReplicas: 10, DesiredReplicas: 10, Reason: "DesiredWithinRange"},
+			},
+		},
+		{
+			name:      "a smaller tolerance lets the same deviation scale",
+			hpa:       cpuHPA(20, &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleDown: noDownWindow}),
+			series:    stepTo(54),
+			tolerance: 0.05,
+			want: map[int]Step{
+				60: {ProposedReplicas: 11, DesiredReplicas: 11, Reason: "DesiredWithinRange"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Run(Input{HPA: test.hpa, Series: test.series, InitialReplicas: 10, Tolerance: test.tolerance})
+			if err != nil {
+				t.Fatalf("running simulation: %v", err)
+			}
+			steps := make(map[int]Step, len(result.Steps))
+			for _, step := range result.Steps {
+				steps[step.Offset] = step
+			}
+			for offset, want := range test.want {
+				got, ok := steps[offset]
+				if !ok {
+					t.Fatalf("no step at %ds", offset)
+				}
+				if (want.ProposedReplicas != 0 && got.ProposedReplicas != want.ProposedReplicas) ||
+					got.DesiredReplicas != want.DesiredReplicas || got.Reason != want.Reason {
+					t.Errorf("at %ds: proposed %d, desired %d (%s), want proposed %d, desired %d (%s)", offset,
+						got.ProposedReplicas, got.DesiredReplicas, got.Reason, want.ProposedReplicas, want.DesiredReplicas, want.Reason)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Package snapshot renders the dashboard's current state into a single offline HTML
+// file, with the stylesheet, data and replica sparklines embedded, for incident tickets
+// and readers without cluster access.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/monitor"
+)
+
+// Template and stylesheet names in the web assets
+const (
+	templateName   = "snapshot.html"
+	stylesheetName = "style.css"
+)
+
+// Sparkline size in SVG user units, and the most points drawn per sparkline
+const (
+	sparklineWidth     = 360
+	sparklineHeight    = 48
+	maxSparklinePoints = 120
+)
+
+// DefaultWindow is the history drawn in sparklines when no window is given
+const DefaultWindow = 24 * time.Hour
+
+// unitPattern matches the unit suffix of a metric target such as 70% or 500m
+var unitPattern = regexp.MustCompile(`(%|[a-zA-Z]+)$`)
+
+// Options describe the report header and the history drawn in sparklines
+type Options struct {
+	Cluster     string
+	GeneratedAt time.Time
+	// Store and Window select the history drawn per HPA; a nil Store draws none
+	Store  *history.Store
+	Window time.Duration
+}
+
+// page is the template data of snapshot.html
+type page struct {
+	Title       string
+	Cluster     string
+	GeneratedAt string
+	Window      string
+	CSS         template.CSS
+	Data        template.JS
+	Stats       stats
+	Cards       []card
+}
+
+// stats are the dashboard's summary counters
+type stats struct {
+	Total, Ready, ActiveScaling int
+	Replicas                    int32
+}
+
+// card is one HPA card, with values formatted the way the dashboard shows them
+type card struct {
+	monitor.HPAStatus
+	Class           string
+	ConditionsTitle string
+	MetricLabel     string
+	MetricValue     string
+	RatioValue      string
+	ToleranceState  string
+	LastScale       string
+	RunbookURL      string
+	Sparkline       *sparkline
+}
+
+// sparkline is a replica history drawn as an SVG polyline scaled to maxReplicas
+type sparkline struct {
+	Width, Height int
+	Points        string
+	// MaxY is the y coordinate of the maxReplicas line
+	MaxY    string
+	Summary string
+}
+
+// Render writes the HTML report for statuses, using snapshot.html and style.css from assets
+func Render(w io.Writer, assets fs.FS, statuses []monitor.HPAStatus, opts Options) error {
+	tmpl, err := template.ParseFS(assets, templateName)
+	if err != nil {
+		return fmt.Errorf("failed to parse snapshot template: %v", err)
+	}
+	css, err := fs.ReadFile(assets, stylesheetName)
+	if err != nil {
+		return fmt.Errorf("failed to read stylesheet: %v", err)
+	}
+	// encoding/json escapes <, > and &, so the data cannot close its script element
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+
+	window := opts.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	p := page{
+		Title:       "HPA Monitor snapshot",
+		Cluster:     opts.Cluster,
+		GeneratedAt: opts.GeneratedAt.UTC().Format(time.RFC3339),
+		CSS:         template.CSS(css),
+		Data:        template.JS(data),
+	}
+	if opts.Cluster != "" {
+		p.Title += " - " + opts.Cluster
+	}
+	if opts.Store != nil {
+		p.Window = formatWindow(window)
+	}
+
+	for _, status := range statuses {
+		p.Stats.Total++
+		if status.Ready {
+			p.Stats.Ready++
+		}
+		if status.CurrentReplicas != status.DesiredReplicas {
+			p.Stats.ActiveScaling++
+		}
+		p.Stats.Replicas += status.CurrentReplicas
+
+		c := newCard(status)
+		if opts.Store != nil {
+			samples := opts.Store.Samples(status.Namespace, status.Name, opts.GeneratedAt.Add(-window))
+			c.Sparkline = newSparkline(samples, status.MaxReplicas)
+		}
+		p.Cards = append(p.Cards, c)
+	}
+
+	return tmpl.Execute(w, p)
+}
+
+// newCard formats an HPA the way the dashboard's createHPACard does
+func newCard(status monitor.HPAStatus) card {
+	c := card{HPAStatus: status, Class: "hpa-card", LastScale: "Never", RatioValue: "N/A"}
+	switch {
+	case !status.Ready:
+		c.Class += " danger"
+	case status.CurrentReplicas != status.DesiredReplicas:
+		c.Class += " warning"
+	}
+
+	if len(status.Conditions) == 0 {
+		c.ConditionsTitle = "Not Ready"
+		if status.Ready {
+			c.ConditionsTitle = "Ready"
+		}
+	} else {
+		lines := make([]string, 0, len(status.Conditions))
+		for _, condition := range status.Conditions {
+			lines = append(lines, fmt.Sprintf("%s=%s (%s) since %s: %s",
+				condition.Type, condition.Status, condition.Reason, condition.LastTransitionTime, condition.Message))
+		}
+		c.ConditionsTitle = strings.Join(lines, "\n")
+	}
+
+	c.MetricLabel = status.PrimaryMetricName
+	if c.MetricLabel == "" {
+		c.MetricLabel = "CPU"
+	}
+	current, target := "N/A", "N/A"
+	if status.PrimaryMetricCurrent != nil {
+		current = *status.PrimaryMetricCurrent
+	} else if status.CurrentCPUUtilization != nil {
+		current = strconv.Itoa(int(*status.CurrentCPUUtilization)) + "%"
+	}
+	if status.PrimaryMetricTarget != nil {
+		target = *status.PrimaryMetricTarget
+	} else if status.TargetCPUUtilization != nil {
+		target = strconv.Itoa(int(*status.TargetCPUUtilization)) + "%"
+	}
+	if current != "N/A" && target != "N/A" {
+		// Show the unit once, e.g. 45 / 70%
+		current = strings.Replace(current, unitPattern.FindString(target), "", 1)
+	}
+	c.MetricValue = current + " / " + target
+
+	if status.Ratio != nil && *status.Ratio != 0 {
+		c.RatioValue = strconv.FormatFloat(*status.Ratio, 'f', 2, 64)
+		if status.Tolerance != 0 {
+			c.ToleranceState = "ok"
+			if math.Abs(*status.Ratio-1.0) > status.Tolerance {
+				c.ToleranceState = "warning"
+			}
+		}
+	}
+	if status.LastScaleTime != nil {
+		c.LastScale = *status.LastScaleTime
+	}
+	if strings.HasPrefix(status.Runbook, "http://") || strings.HasPrefix(status.Runbook, "https://") {
+		c.RunbookURL = status.Runbook
+	}
+	return c
+}
+
+// newSparkline draws current replicas over time, averaging samples into at most
+// maxSparklinePoints points. It returns nil without samples.
+func newSparkline(samples []history.Sample, maxReplicas int32) *sparkline {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	top := float64(maxReplicas)
+	for _, sample := range samples {
+		top = math.Max(top, float64(sample.CurrentReplicas))
+	}
+	if top < 1 {
+		top = 1
+	}
+	y := func(replicas float64) string {
+		// Leave a pixel at the top and bottom so the line is never clipped
+		return strconv.FormatFloat(float64(sparklineHeight-1)-replicas/top*float64(sparklineHeight-2), 'f', 1, 64)
+	}
+
+	buckets := len(samples)
+	if buckets > maxSparklinePoints {
+		buckets = maxSparklinePoints
+	}
+	points := make([]string, 0, buckets)
+	for b := 0; b < buckets; b++ {
+		start, end := b*len(samples)/buckets, (b+1)*len(samples)/buckets
+		sum := 0.0
+		for _, sample := range samples[start:end] {
+			sum += float64(sample.CurrentReplicas)
+		}
+		x := 0.0
+		if buckets > 1 {
+			x = float64(b) / float64(buckets-1) * sparklineWidth
+		}
+		points = append(points, strconv.FormatFloat(x, 'f', 1, 64)+","+y(sum/float64(end-start)))
+	}
+	if len(points) == 1 {
+		// A single sample is drawn as a flat line
+		points = append(points, strconv.Itoa(sparklineWidth)+","+strings.Split(points[0], ",")[1])
+	}
+
+	summary := history.Summarize(samples)
+	return &sparkline{
+		Width:  sparklineWidth,
+		Height: sparklineHeight,
+		Points: strings.Join(points, " "),
+		MaxY:   y(float64(maxReplicas)),
+		Summary: fmt.Sprintf("avg %.1f, p95 %.0f, peak %d replicas; %s at max",
+			summary.AvgReplicas, summary.P95Replicas, summary.PeakReplicas,
+			(time.Duration(summary.TimeAtMax) * time.Second).String()),
+	}
+}
+
+// formatWindow renders a window as days when it is a whole number of days, e.g. 7d, and
+// as a duration without zero units otherwise, e.g. 6h or 1h30m
+func formatWindow(window time.Duration) string {
+	if window%(24*time.Hour) == 0 {
+		return strconv.Itoa(int(window/(24*time.Hour))) + "d"
+	}
+	text := window.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
@@ -0,0 +1,328 @@
+package transition
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"hpa-monitor/pkg/history"
+	"hpa-monitor/pkg/logger"
+	"hpa-monitor/pkg/monitor"
+)
+
+// maxTransitions bounds the in-memory transition buffer
+const maxTransitions = 10000
+
+// Directions of a replica change
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// Sources of a transition
+const (
+	SourceObservedStatus = "observed-status"
+	SourceEvent          = "event"
+)
+
+// Fields whose changes are reported for the observed-status source
+const (
+	FieldCurrentReplicas = "currentReplicas"
+	FieldDesiredReplicas = "desiredReplicas"
+)
+
+// ScaleTransition is one change of an HPA's replica count
+type ScaleTransition struct {
+	// ID increases with every transition observed: the observation time in Unix
+	// microseconds, raised above the previous ID when two fall in the same microsecond
+	ID            int64   `json:"id"`
+	Cluster       string  `json:"cluster,omitempty"`
+	Namespace     string  `json:"namespace"`
+	Name          string  `json:"name"`
+	Field         string  `json:"field"`
+	From          *int32  `json:"from"`
+	To            int32   `json:"to"`
+	Direction     string  `json:"direction"`
+	TriggerMetric string  `json:"triggerMetric,omitempty"`
+	TriggerValue  *string `json:"triggerValue,omitempty"`
+	// Timestamp is when the scale happened: the event's time for event transitions, which
+	// can precede earlier observations, and otherwise ObservedAt
+	Timestamp time.Time `json:"timestamp"`
+	// ObservedAt is when the tracker saw the transition; transitions are kept in this order
+	ObservedAt time.Time `json:"observedAt"`
+	Source     string    `json:"source"`
+}
+
+// observation is the last seen state of one HPA
+type observation struct {
+	currentReplicas int32
+	desiredReplicas int32
+	events          map[string]bool
+	// unmatchedEvent and unmatchedStatus hold the target of a rescale reported by only one
+	// source, so the other source's report of it in the next snapshot is not repeated
+	unmatchedEvent  *int32
+	unmatchedStatus *int32
+}
+
+// Tracker derives scale transitions from successive HPA snapshots and keeps recent ones
+type Tracker struct {
+	mu           sync.RWMutex
+	cluster      string
+	retention    time.Duration
+	interval     time.Duration
+	observations map[string]observation
+	transitions  []ScaleTransition
+	lastID       int64
+}
+
+// NewTracker creates a tracker that labels transitions with cluster and keeps them for retention
+func NewTracker(cluster string, retention time.Duration) *Tracker {
+	return &Tracker{
+		cluster:      cluster,
+		retention:    retention,
+		observations: make(map[string]observation),
+	}
+}
+
+// Observe compares statuses with the previous snapshot and returns the new transitions.
+// The first snapshot of an HPA only sets the baseline.
+func (t *Tracker) Observe(statuses []monitor.HPAStatus, at time.Time) []ScaleTransition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var found []ScaleTransition
+	present := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		key := history.Key(status.Namespace, status.Name)
+		present[key] = true
+		previous, seen := t.observations[key]
+
+		current := observation{
+			currentReplicas: status.CurrentReplicas,
+			desiredReplicas: status.DesiredReplicas,
+			events:          make(map[string]bool),
+		}
+		var rescales []ScaleTransition
+		for _, event := range status.Events {
+			if event.NewReplicas == nil {
+				continue
+			}
+			eventKey := event.LastTimestamp + "|" + event.Message
+			current.events[eventKey] = true
+			if seen && !previous.events[eventKey] {
+				rescales = append(rescales, t.fromEvent(status, event, previous.desiredReplicas, at))
+			}
+		}
+		if !seen {
+			t.observations[key] = current
+			continue
+		}
+
+		if previous.currentReplicas != current.currentReplicas {
+			found = append(found, t.fromStatus(status, FieldCurrentReplicas, previous.currentReplicas, current.currentReplicas, at))
+		}
+		var desired *ScaleTransition
+		if previous.desiredReplicas != current.desiredReplicas {
+			change := t.fromStatus(status, FieldDesiredReplicas, previous.desiredReplicas, current.desiredReplicas, at)
+			desired = &change
+		}
+
+		// A desiredReplicas change and its SuccessfulRescale event describe the same scale, whether
+		// they are seen in the same snapshot or in consecutive ones; only the event is kept
+		for _, rescale := range rescales {
+			switch {
+			case desired != nil && desired.To == rescale.To:
+				if rescale.From == nil {
+					rescale.From = desired.From
+					rescale.Direction = desired.Direction
+				}
+				desired = nil
+			case previous.unmatchedStatus != nil && *previous.unmatchedStatus == rescale.To:
+				continue
+			default:
+				to := rescale.To
+				current.unmatchedEvent = &to
+			}
+			found = append(found, rescale)
+		}
+		if desired != nil {
+			if previous.unmatchedEvent == nil || *previous.unmatchedEvent != desired.To {
+				current.unmatchedStatus = &desired.To
+				found = append(found, *desired)
+			}
+		}
+		t.observations[key] = current
+	}
+
+	// Forget deleted or descoped HPAs so a returning HPA starts from a new baseline
+	for key := range t.observations {
+		if !present[key] {
+			delete(t.observations, key)
+		}
+	}
+
+	for i := range found {
+		found[i].ID = max(at.UnixMicro(), t.lastID+1)
+		t.lastID = found[i].ID
+	}
+	t.transitions = append(t.transitions, found...)
+	t.prune(at)
+	return found
+}
+
+// LastID returns the ID of the latest transition observed, 0 before the first
+func (t *Tracker) LastID() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lastID
+}
+
+// After returns the retained transitions with an ID above id, in the order observed
+func (t *Tracker) After(id int64) []ScaleTransition {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := []ScaleTransition{}
+	for _, transition := range t.transitions {
+		if transition.ID > id {
+			result = append(result, transition)
+		}
+	}
+	return result
+}
+
+// Since returns the retained transitions observed at or after since, in the order observed
+func (t *Tracker) Since(since time.Time) []ScaleTransition {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := []ScaleTransition{}
+	for _, transition := range t.transitions {
+		if !transition.ObservedAt.Before(since) {
+			result = append(result, transition)
+		}
+	}
+	return result
+}
+
+// SetInterval changes the polling interval of Run, taking effect after the current wait
+func (t *Tracker) SetInterval(interval time.Duration) {
+	t.mu.Lock()
+	t.interval = interval
+	t.mu.Unlock()
+}
+
+// Run observes HPA statuses every interval and passes new transitions to notify until ctx is
+// cancelled. An interval set with SetInterval takes precedence, so a tracker run again after
+// regaining leadership keeps a reloaded interval; it starts from a new baseline.
+func (t *Tracker) Run(ctx context.Context, hpaMonitor *monitor.HPAMonitor, interval time.Duration, notify func([]ScaleTransition)) {
+	log := logger.GetLogger()
+	t.mu.Lock()
+	if t.interval == 0 {
+		t.interval = interval
+	}
+	interval = t.interval
+	t.observations = make(map[string]observation)
+	t.mu.Unlock()
+	log.WithField("interval", interval.String()).Info("Scale transition tracker started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		statuses, err := hpaMonitor.GetHPAStatus(ctx)
+		if err != nil {
+			log.WithError(err).Error("Failed to collect HPA status for scale transitions")
+		} else if found := t.Observe(statuses, hpaMonitor.Now()); len(found) > 0 {
+			log.WithField("count", len(found)).Debug("Scale transitions observed")
+			notify(found)
+		}
+
+		t.mu.RLock()
+		next := t.interval
+		t.mu.RUnlock()
+		if next != interval {
+			interval = next
+			ticker.Reset(interval)
+			log.WithField("interval", interval.String()).Info("Scale transition interval changed")
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Scale transition tracker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fromStatus builds a transition from a change in the observed replica counts
+func (t *Tracker) fromStatus(status monitor.HPAStatus, field string, from, to int32, at time.Time) ScaleTransition {
+	return ScaleTransition{
+		Cluster:       t.cluster,
+		Namespace:     status.Namespace,
+		Name:          status.Name,
+		Field:         field,
+		From:          &from,
+		To:            to,
+		Direction:     direction(from, to),
+		TriggerMetric: status.PrimaryMetricName,
+		TriggerValue:  status.PrimaryMetricCurrent,
+		Timestamp:     at,
+		ObservedAt:    at,
+		Source:        SourceObservedStatus,
+	}
+}
+
+// fromEvent builds a transition from a SuccessfulRescale event. Without the previous size
+// the direction comes from the event's category, or else from the last desired replicas.
+func (t *Tracker) fromEvent(status monitor.HPAStatus, event monitor.Event, lastDesired int32, at time.Time) ScaleTransition {
+	transition := ScaleTransition{
+		Cluster:       t.cluster,
+		Namespace:     status.Namespace,
+		Name:          status.Name,
+		Field:         FieldDesiredReplicas,
+		From:          event.OldReplicas,
+		To:            *event.NewReplicas,
+		TriggerMetric: event.TriggerMetric,
+		Timestamp:     at,
+		ObservedAt:    at,
+		Source:        SourceEvent,
+	}
+	switch {
+	case event.OldReplicas != nil:
+		transition.Direction = direction(*event.OldReplicas, *event.NewReplicas)
+	case event.Category == monitor.CategoryRescaleUp:
+		transition.Direction = DirectionUp
+	case event.Category == monitor.CategoryRescaleDown:
+		transition.Direction = DirectionDown
+	default:
+		transition.Direction = direction(lastDesired, *event.NewReplicas)
+	}
+	if eventTime, err := time.Parse(time.RFC3339, event.LastTimestamp); err == nil {
+		transition.Timestamp = eventTime
+	}
+	return transition
+}
+
+// prune drops transitions observed before retention and beyond the buffer limit. The
+// buffer is in observation order, so expired transitions are at its start.
+func (t *Tracker) prune(at time.Time) {
+	cutoff := at.Add(-t.retention)
+	start := 0
+	for start < len(t.transitions) && t.transitions[start].ObservedAt.Before(cutoff) {
+		start++
+	}
+	if len(t.transitions)-start > maxTransitions {
+		start = len(t.transitions) - maxTransitions
+	}
+	t.transitions = t.transitions[start:]
+}
+
+// direction reports whether a change from from to to scales up or down
+func direction(from, to int32) string {
+	if to < from {
+		return DirectionDown
+	}
+	return DirectionUp
+}
@@ -0,0 +1,116 @@
+package transition
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hpa-monitor/pkg/monitor"
+)
+
+// rescale is a classified SuccessfulRescale event without the previous size
+func rescale(newReplicas int32, category string) monitor.Event {
+	return monitor.Event{
+		Reason:        "SuccessfulRescale",
+		Message:       fmt.Sprintf("New size: %d", newReplicas),
+		LastTimestamp: "2024-01-01T00:00:00Z",
+		Count:         1,
+		Category:      category,
+		NewReplicas:   &newReplicas,
+	}
+}
+
+// snapshot returns the status of shop/checkout with the given replicas and events
+func snapshot(current, desired int32, events ...monitor.Event) []monitor.HPAStatus {
+	return []monitor.HPAStatus{{
+		Namespace:       "shop",
+		Name:            "checkout",
+		CurrentReplicas: current,
+		DesiredReplicas: desired,
+		Events:          events,
+	}}
+}
+
+func TestObserveReportsEachRescaleOnce(t *testing.T) {
+	up := rescale(8, monitor.CategoryRescaleUp)
+	unknown := rescale(6, monitor.CategoryOther)
+
+	tests := []struct {
+		name      string
+		snapshots [][]monitor.HPAStatus
+		// want lists the transitions as "field from->to direction source"
+		want []string
+	}{
+		{
+			name:      "status and event in one snapshot",
+			snapshots: [][]monitor.HPAStatus{snapshot(3, 3), snapshot(3, 8, up)},
+			want:      []string{"desiredReplicas 3->8 up event"},
+		},
+		{
+			name:      "event before status",
+			snapshots: [][]monitor.HPAStatus{snapshot(3, 3), snapshot(3, 3, up), snapshot(3, 8, up)},
+			want:      []string{"desiredReplicas ?->8 up event"},
+		},
+		{
+			name:      "status before event",
+			snapshots: [][]monitor.HPAStatus{snapshot(3, 3), snapshot(3, 8), snapshot(8, 8, up)},
+			want:      []string{"desiredReplicas 3->8 up observed-status", "currentReplicas 3->8 up observed-status"},
+		},
+		{
+			name:      "event of unknown direction",
+			snapshots: [][]monitor.HPAStatus{snapshot(10, 10), snapshot(10, 10, unknown)},
+			want:      []string{"desiredReplicas ?->6 down event"},
+		},
+	}
+	for _, test := range tests {
+		tracker := NewTracker("", time.Hour)
+		var got []string
+		for i, statuses := range test.snapshots {
+			at := time.Date(2024, 1, 1, 1, i, 0, 0, time.UTC)
+			for _, transition := range tracker.Observe(statuses, at) {
+				from := "?"
+				if transition.From != nil {
+					from = fmt.Sprint(*transition.From)
+				}
+				got = append(got, fmt.Sprintf("%s %s->%d %s %s", transition.Field, from, transition.To, transition.Direction, transition.Source))
+			}
+		}
+		if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTransitionsAreKeptInObservationOrder(t *testing.T) {
+	tracker := NewTracker("", time.Hour)
+	at := func(minutes, seconds int) time.Time {
+		return time.Date(2024, 1, 1, 1, minutes, seconds, 0, time.UTC)
+	}
+	// The event is from 00:00, an hour before the status changes observed ahead of it
+	up := rescale(8, monitor.CategoryRescaleUp)
+	tracker.Observe(snapshot(3, 3), at(0, 0))
+	tracker.Observe(snapshot(4, 4), at(1, 0))
+	tracker.Observe(snapshot(4, 4, up), at(2, 0))
+
+	sources := func(transitions []ScaleTransition) string {
+		var result []string
+		for _, transition := range transitions {
+			result = append(result, transition.Field+" "+transition.Source)
+		}
+		return strings.Join(result, ", ")
+	}
+	want := "currentReplicas observed-status, desiredReplicas observed-status, desiredReplicas event"
+	if got := sources(tracker.Since(time.Time{})); got != want {
+		t.Errorf("all transitions: got %s, want %s", got, want)
+	}
+	if got := sources(tracker.Since(at(1, 30))); got != "desiredReplicas event" {
+		t.Errorf("transitions observed since 01:01:30: got %s, want the event", got)
+	}
+
+	// An hour later only the status changes have expired, though the event happened first
+	tracker.Observe(snapshot(4, 4, up), at(61, 30))
+	if got := sources(tracker.Since(time.Time{})); got != "desiredReplicas event" {
+		t.Errorf("after pruning: got %s, want the event", got)
+	}
+}
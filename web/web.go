@@ -0,0 +1,21 @@
+// Package web holds the dashboard page, the snapshot template and their stylesheet,
+// embedded so the binary serves them from any working directory.
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+)
+
+//go:embed *.html *.css
+var files embed.FS
+
+// FS returns the dashboard assets, read from dir when it is set and from the copies
+// embedded at build time otherwise
+func FS(dir string) fs.FS {
+	if dir != "" {
+		return os.DirFS(dir)
+	}
+	return files
+}